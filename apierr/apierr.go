@@ -0,0 +1,150 @@
+package apierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+
+	"github.com/aws/aws-lambda-go/events"
+	pkgerrors "github.com/pulpfree/go-errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Code string is the machine-readable error category returned to clients
+type Code string
+
+// Code constants
+const (
+	CodeValidation      Code = "validation"
+	CodeNotFound        Code = "not_found"
+	CodeUnauthorized    Code = "unauthorized"
+	CodeUpstreamTimeout Code = "upstream_timeout"
+	CodeInternal        Code = "internal"
+)
+
+// statusCodes maps each Code to its HTTP status
+// unauthorized is 403 as API Gateway rejects unauthenticated requests with 401 before they reach us
+var statusCodes = map[Code]int{
+	CodeValidation:      400,
+	CodeNotFound:        404,
+	CodeUnauthorized:    403,
+	CodeUpstreamTimeout: 504,
+	CodeInternal:        500,
+}
+
+// Error struct tags an error with its Code, the wrapped error is usually a pkgerrors.StdError
+// Msg, when set, is the message returned to the client in place of the wrapped error's
+// Fields lists each invalid input field of a validation error
+type Error struct {
+	Code   Code
+	Err    error
+	Fields []*FieldError
+	Msg    string
+}
+
+// FieldError struct is the problem with a single request input field
+type FieldError struct {
+	Field   string `json:"field"`   // Input field name as submitted, ie: stationID
+	Message string `json:"message"` // Error message
+}
+
+// Response struct is the JSON body of an error response, it extends the lambda-go-proxy-response body with ErrorCode
+type Response struct {
+	Code      int           `json:"code"`             // HTTP status code
+	Data      interface{}   `json:"data"`             // Data payload
+	ErrorCode Code          `json:"errorCode"`        // Machine-readable error category
+	Fields    []*FieldError `json:"fields,omitempty"` // Invalid input fields, validation errors only
+	Message   string        `json:"message"`          // Error message
+	Status    string        `json:"status"`           // Status code (error|fail)
+	Timestamp int64         `json:"timestamp"`        // Machine-readable UTC timestamp in seconds since EPOCH
+}
+
+// New function tags err with code
+func New(code Code, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+// Error method
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap method
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GetCode function returns the Code of err, untagged timeouts are CodeUpstreamTimeout and any other
+// untagged error is CodeInternal
+func GetCode(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	if IsTimeout(err) {
+		return CodeUpstreamTimeout
+	}
+	return CodeInternal
+}
+
+// IsTimeout function reports whether err is a context deadline or network timeout
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// StatusCode function returns the HTTP status for err
+func StatusCode(err error) int {
+	return statusCodes[GetCode(err)]
+}
+
+// ProxyRes function returns the API Gateway response for err, with the HTTP status and error code
+// of its category and, as with lambda-go-proxy-response, the StdError Msg as the client message
+func ProxyRes(err error, hdrs map[string]string, timestamp int64) events.APIGatewayProxyResponse {
+
+	code := GetCode(err)
+	resp := Response{
+		Code:      statusCodes[code],
+		ErrorCode: code,
+		Fields:    fields(err),
+		Message:   message(err),
+		Status:    "error",
+		Timestamp: timestamp,
+	}
+	if resp.Code < 500 {
+		resp.Status = "fail"
+		log.Warn(err)
+	} else {
+		log.Error(err)
+	}
+	body, _ := json.Marshal(&resp)
+
+	return events.APIGatewayProxyResponse{Body: string(body), Headers: hdrs, StatusCode: resp.Code}
+}
+
+func fields(err error) []*FieldError {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+	return nil
+}
+
+func message(err error) string {
+	var e *Error
+	if errors.As(err, &e) && e.Msg != "" {
+		return e.Msg
+	}
+	var stdError *pkgerrors.StdError
+	if errors.As(err, &stdError) {
+		return stdError.Msg
+	}
+	if GetCode(err) == CodeInternal {
+		return "Internal error"
+	}
+	return err.Error()
+}
@@ -0,0 +1,77 @@
+package apierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+}
+
+// TestGetCode method
+func (s *UnitSuite) TestGetCode() {
+	notFound := New(CodeNotFound, &pkgerrors.StdError{Caller: "db.GetDay", Msg: "No records found matching criteria"})
+	s.Equal(CodeNotFound, GetCode(notFound))
+	s.Equal(CodeNotFound, GetCode(fmt.Errorf("wrapped: %w", notFound)))
+	s.Equal(CodeUpstreamTimeout, GetCode(fmt.Errorf("find: %w", context.DeadlineExceeded)))
+	s.Equal(CodeInternal, GetCode(errors.New("boom")))
+
+	s.Equal(404, StatusCode(notFound))
+	s.Equal(500, StatusCode(errors.New("boom")))
+}
+
+// TestProxyRes method
+func (s *UnitSuite) TestProxyRes() {
+	hdrs := map[string]string{"Content-Type": "application/json"}
+
+	err := New(CodeValidation, &pkgerrors.StdError{Err: "empty input.Date", Caller: "validate.SetRequest", Msg: "Error missing input.Date"})
+	res := ProxyRes(err, hdrs, 1597176000)
+	s.Equal(400, res.StatusCode)
+
+	var body Response
+	s.NoError(json.Unmarshal([]byte(res.Body), &body))
+	s.Equal(400, body.Code)
+	s.Equal(CodeValidation, body.ErrorCode)
+	s.Equal("Error missing input.Date", body.Message)
+	s.Equal("fail", body.Status)
+	s.Equal(int64(1597176000), body.Timestamp)
+	s.Nil(body.Fields)
+
+	err = &Error{Code: CodeValidation, Err: errors.New("2 invalid fields"), Fields: []*FieldError{
+		{Field: "date", Message: "Error missing input.Date"},
+		{Field: "stationID", Message: "Error missing input.StationID"},
+	}, Msg: "Error invalid input: date, stationID"}
+	res = ProxyRes(err, hdrs, 0)
+	s.Equal(400, res.StatusCode)
+	s.NoError(json.Unmarshal([]byte(res.Body), &body))
+	s.Equal("Error invalid input: date, stationID", body.Message)
+	s.Len(body.Fields, 2)
+	s.Equal("stationID", body.Fields[1].Field)
+	body.Fields = nil
+
+	err = &Error{Code: CodeUnauthorized, Err: errors.New("jdoe may not request day reports"), Msg: "Forbidden"}
+	res = ProxyRes(err, hdrs, 0)
+	s.Equal(403, res.StatusCode)
+	s.NoError(json.Unmarshal([]byte(res.Body), &body))
+	s.Equal("Forbidden", body.Message)
+
+	res = ProxyRes(errors.New("connection reset"), hdrs, 0)
+	s.Equal(500, res.StatusCode)
+	s.NoError(json.Unmarshal([]byte(res.Body), &body))
+	s.Equal(CodeInternal, body.ErrorCode)
+	s.Equal("Internal error", body.Message)
+	s.Equal("error", body.Status)
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/config"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// anyValue grants access to every station or report type
+const anyValue = "*"
+
+// auditAccess is the GroupAccess report type that allows listing a station's report audit log
+const auditAccess = "audit"
+
+// ErrForbidden is returned, wrapped and tagged apierr.CodeUnauthorized, when the principal may not request the report
+var ErrForbidden = errors.New("Forbidden")
+
+// Authorize function checks the principal may request the report type for the station
+// members of an admin group may request any report, otherwise one of the principal's groups
+// must allow both the station and the report type
+// a station requested by name must first be resolved to its ID, grants are by ID only
+func Authorize(p *model.Principal, req *model.ReportRequest, cfg *config.Config) error {
+	if req.StationID.IsZero() {
+		return forbidden(fmt.Errorf("%w: station %q not resolved to an ID", ErrForbidden, req.StationName))
+	}
+	return authorize(p, req.StationID.Hex(), req.ReportType.String(), cfg)
+}
+
+// AuthorizeAudit function checks the principal may list the report audit log for the station,
+// allowed for admin groups and groups with the "audit" report type for the station
+func AuthorizeAudit(p *model.Principal, stationID primitive.ObjectID, cfg *config.Config) error {
+	return authorize(p, stationID.Hex(), auditAccess, cfg)
+}
+
+// AuthorizeShiftList function checks the principal may list the station's shifts, allowed to anyone
+// who may request shift reports for the station
+func AuthorizeShiftList(p *model.Principal, stationID primitive.ObjectID, cfg *config.Config) error {
+	return authorize(p, stationID.Hex(), model.ShiftReport.String(), cfg)
+}
+
+// StationAccess function reports whether the principal may request any report for the station,
+// admin groups may access every station
+func StationAccess(p *model.Principal, stationID primitive.ObjectID, cfg *config.Config) bool {
+	if p == nil || cfg.Authorization == nil {
+		return false
+	}
+
+	az := cfg.Authorization
+	for _, g := range az.AdminGroups {
+		if p.InGroup(g) {
+			return true
+		}
+	}
+	for _, g := range p.Groups {
+		if access, ok := az.Groups[g]; ok && access != nil && contains(access.Stations, stationID.Hex()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func authorize(p *model.Principal, stationID, reportType string, cfg *config.Config) error {
+	if p == nil {
+		return forbidden(fmt.Errorf("%w: no authenticated user", ErrForbidden))
+	}
+
+	az := cfg.Authorization
+	if az == nil {
+		return forbidden(fmt.Errorf("%w: no authorization configured", ErrForbidden))
+	}
+	for _, g := range az.AdminGroups {
+		if p.InGroup(g) {
+			return nil
+		}
+	}
+
+	for _, g := range p.Groups {
+		access, ok := az.Groups[g]
+		if !ok || access == nil {
+			continue
+		}
+		if contains(access.Stations, stationID) && contains(access.ReportTypes, reportType) {
+			return nil
+		}
+	}
+
+	return forbidden(fmt.Errorf("%w: %s may not request %s reports for station %s", ErrForbidden, p.Name(), reportType, stationID))
+}
+
+// forbidden function tags err as unauthorized, the detail is logged but clients only see ErrForbidden
+func forbidden(err error) error {
+	return &apierr.Error{Code: apierr.CodeUnauthorized, Err: err, Msg: ErrForbidden.Error()}
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == anyValue || v == val {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/pulpfree/gsales-pdf-reports/config"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	bridgeID  = "56cf1815982d82b0f3000001"
+	collierID = "56cf1815982d82b0f3000006"
+)
+
+func (s *UnitSuite) authConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Authorization = &config.Authorization{
+		AdminGroups: []string{"admin"},
+		Groups: map[string]*config.GroupAccess{
+			"bridge-managers": {ReportTypes: []string{"day", "shift"}, Stations: []string{bridgeID}},
+			"bridge-staff":    {ReportTypes: []string{"shift"}, Stations: []string{bridgeID}},
+			"accounting":      {ReportTypes: []string{"*"}, Stations: []string{"*"}},
+		},
+	}
+	return cfg
+}
+
+func reportRequest(stationID string, rt model.ReportType) *model.ReportRequest {
+	id, _ := primitive.ObjectIDFromHex(stationID)
+	return &model.ReportRequest{ReportType: &rt, StationID: id}
+}
+
+// TestAuthorize method
+func (s *UnitSuite) TestAuthorize() {
+	cfg := s.authConfig()
+
+	admin := &model.Principal{Groups: []string{"admin"}}
+	s.NoError(Authorize(admin, reportRequest(collierID, model.DayReport), cfg))
+
+	accounting := &model.Principal{Groups: []string{"accounting"}}
+	s.NoError(Authorize(accounting, reportRequest(collierID, model.ShiftReport), cfg))
+
+	manager := &model.Principal{Groups: []string{"bridge-managers"}}
+	s.NoError(Authorize(manager, reportRequest(bridgeID, model.DayReport), cfg))
+	err := Authorize(manager, reportRequest(collierID, model.DayReport), cfg)
+	s.True(errors.Is(err, ErrForbidden))
+
+	staff := &model.Principal{Groups: []string{"bridge-staff"}}
+	s.NoError(Authorize(staff, reportRequest(bridgeID, model.ShiftReport), cfg))
+	s.True(errors.Is(Authorize(staff, reportRequest(bridgeID, model.DayReport), cfg), ErrForbidden))
+}
+
+// TestAuthorizeDenied method
+func (s *UnitSuite) TestAuthorizeDenied() {
+	cfg := s.authConfig()
+
+	s.True(errors.Is(Authorize(nil, reportRequest(bridgeID, model.DayReport), cfg), ErrForbidden))
+	s.True(errors.Is(Authorize(&model.Principal{}, reportRequest(bridgeID, model.DayReport), cfg), ErrForbidden))
+
+	cfg.Authorization = nil
+	admin := &model.Principal{Groups: []string{"admin"}}
+	s.True(errors.Is(Authorize(admin, reportRequest(bridgeID, model.DayReport), cfg), ErrForbidden))
+}
+
+// TestAuthorizeUnresolvedStation method checks a station requested by name isn't authorized until resolved to its ID
+func (s *UnitSuite) TestAuthorizeUnresolvedStation() {
+	cfg := s.authConfig()
+	rt := model.DayReport
+	req := &model.ReportRequest{ReportType: &rt, StationName: "Bridge"}
+
+	accounting := &model.Principal{Groups: []string{"accounting"}}
+	s.True(errors.Is(Authorize(accounting, req, cfg), ErrForbidden))
+
+	req.StationID, _ = primitive.ObjectIDFromHex(bridgeID)
+	s.NoError(Authorize(accounting, req, cfg))
+	s.NoError(Authorize(&model.Principal{Groups: []string{"bridge-managers"}}, req, cfg))
+}
+
+// TestAuthorizeAudit method
+func (s *UnitSuite) TestAuthorizeAudit() {
+	cfg := s.authConfig()
+	cfg.Authorization.Groups["bridge-auditors"] = &config.GroupAccess{ReportTypes: []string{"audit"}, Stations: []string{bridgeID}}
+	bridge, _ := primitive.ObjectIDFromHex(bridgeID)
+	collier, _ := primitive.ObjectIDFromHex(collierID)
+
+	s.NoError(AuthorizeAudit(&model.Principal{Groups: []string{"admin"}}, collier, cfg))
+	s.NoError(AuthorizeAudit(&model.Principal{Groups: []string{"accounting"}}, collier, cfg))
+
+	auditor := &model.Principal{Groups: []string{"bridge-auditors"}}
+	s.NoError(AuthorizeAudit(auditor, bridge, cfg))
+	s.True(errors.Is(AuthorizeAudit(auditor, collier, cfg), ErrForbidden))
+	s.True(errors.Is(Authorize(auditor, reportRequest(bridgeID, model.ShiftReport), cfg), ErrForbidden))
+
+	manager := &model.Principal{Groups: []string{"bridge-managers"}}
+	s.True(errors.Is(AuthorizeAudit(manager, bridge, cfg), ErrForbidden))
+}
+
+// TestAuthorizeShiftList method
+func (s *UnitSuite) TestAuthorizeShiftList() {
+	cfg := s.authConfig()
+	cfg.Authorization.Groups["bridge-day"] = &config.GroupAccess{ReportTypes: []string{"day"}, Stations: []string{bridgeID}}
+	bridge, _ := primitive.ObjectIDFromHex(bridgeID)
+	collier, _ := primitive.ObjectIDFromHex(collierID)
+
+	staff := &model.Principal{Groups: []string{"bridge-staff"}}
+	s.NoError(AuthorizeShiftList(staff, bridge, cfg))
+	s.True(errors.Is(AuthorizeShiftList(staff, collier, cfg), ErrForbidden))
+
+	s.True(errors.Is(AuthorizeShiftList(&model.Principal{Groups: []string{"bridge-day"}}, bridge, cfg), ErrForbidden))
+}
+
+// TestStationAccess method
+func (s *UnitSuite) TestStationAccess() {
+	cfg := s.authConfig()
+	bridge, _ := primitive.ObjectIDFromHex(bridgeID)
+	collier, _ := primitive.ObjectIDFromHex(collierID)
+
+	s.True(StationAccess(&model.Principal{Groups: []string{"admin"}}, collier, cfg))
+	s.True(StationAccess(&model.Principal{Groups: []string{"accounting"}}, collier, cfg))
+
+	staff := &model.Principal{Groups: []string{"bridge-staff"}}
+	s.True(StationAccess(staff, bridge, cfg))
+	s.False(StationAccess(staff, collier, cfg))
+	s.False(StationAccess(nil, bridge, cfg))
+}
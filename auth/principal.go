@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// Cognito claim names
+const (
+	claimEmail    = "email"
+	claimGroups   = "cognito:groups"
+	claimSub      = "sub"
+	claimUsername = "cognito:username"
+)
+
+// GetPrincipal function returns the principal from the Cognito user pool authorizer claims on req
+// it returns nil when the request carries no claims, ie: when invoked locally without an authorizer
+func GetPrincipal(req events.APIGatewayProxyRequest) *model.Principal {
+	claims, ok := req.RequestContext.Authorizer["claims"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return &model.Principal{
+		Email:    claimString(claims, claimEmail),
+		Groups:   claimList(claims, claimGroups),
+		Sub:      claimString(claims, claimSub),
+		Username: claimString(claims, claimUsername),
+	}
+}
+
+// ======================== Helper Functions =================================================== //
+
+func claimString(claims map[string]interface{}, key string) string {
+	s, _ := claims[key].(string)
+	return s
+}
+
+// claimList function returns a multi-valued claim, API Gateway passes these through either as a
+// list or flattened to a string, ie: "[admin managers]" or "admin,managers"
+func claimList(claims map[string]interface{}, key string) (list []string) {
+	switch v := claims[key].(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+	case []string:
+		list = append(list, v...)
+	case string:
+		v = strings.Trim(v, "[]")
+		list = strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+	return list
+}
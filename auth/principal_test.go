@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	req events.APIGatewayProxyRequest
+}
+
+// SetupTest method
+func (s *UnitSuite) SetupTest() {
+	s.req = events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{
+				"claims": map[string]interface{}{
+					"cognito:groups":   "[admin managers]",
+					"cognito:username": "jdoe",
+					"email":            "jdoe@example.com",
+					"sub":              "7d8a3c9e-1111-2222-3333-444455556666",
+				},
+			},
+		},
+	}
+}
+
+// TestGetPrincipal method
+func (s *UnitSuite) TestGetPrincipal() {
+	p := GetPrincipal(s.req)
+	s.Equal("7d8a3c9e-1111-2222-3333-444455556666", p.Sub)
+	s.Equal("jdoe@example.com", p.Name())
+	s.Equal([]string{"admin", "managers"}, p.Groups)
+	s.True(p.InGroup("managers"))
+	s.False(p.InGroup("staff"))
+}
+
+// TestGetPrincipalNoClaims method
+func (s *UnitSuite) TestGetPrincipalNoClaims() {
+	p := GetPrincipal(events.APIGatewayProxyRequest{})
+	s.Nil(p)
+	s.Equal("", p.Name())
+	s.False(p.InGroup("admin"))
+}
+
+// TestClaimList method
+func (s *UnitSuite) TestClaimList() {
+	claims := map[string]interface{}{
+		"list":   []interface{}{"admin", "", "managers"},
+		"commas": "admin,managers",
+	}
+	s.Equal([]string{"admin", "managers"}, claimList(claims, "list"))
+	s.Equal([]string{"admin", "managers"}, claimList(claims, "commas"))
+	s.Nil(claimList(claims, "missing"))
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -36,13 +36,22 @@ func NewS3(cfg *config.Config) (service *S3Service, err error) {
 
 // PutFile method
 func (s *S3Service) PutFile(prefix string, file *bytes.Buffer) (key string, err error) {
+	return s.putObject(prefix, file, "application/pdf")
+}
+
+// PutSignature method stores a detached PKCS#7 signature, alongside the PDF it signs
+func (s *S3Service) PutSignature(prefix string, sig *bytes.Buffer) (key string, err error) {
+	return s.putObject(prefix, sig, "application/pkcs7-signature")
+}
+
+func (s *S3Service) putObject(prefix string, file *bytes.Buffer, contentType string) (key string, err error) {
 
 	uploader := s3manager.NewUploader(s.session)
 	_, err = uploader.Upload(&s3manager.UploadInput{
 		Bucket:             aws.String(s.cfg.S3Bucket),
 		Key:                aws.String(prefix),
 		Body:               file,
-		ContentType:        aws.String("application/pdf"),
+		ContentType:        aws.String(contentType),
 		ContentDisposition: aws.String("attachment"),
 	})
 	if err != nil {
@@ -8,10 +8,13 @@ import (
 	"path"
 	"reflect"
 	"strings"
+	"time"
+	_ "time/tzdata" // the Lambda runtime image may not include the zoneinfo database
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/pulpfree/gsales-pdf-reports/model"
 	"gopkg.in/yaml.v2"
 )
 
@@ -34,10 +37,20 @@ const (
 
 const defaultFileName = "defaults.yml"
 
+// defaultBrandingKey is the Branding profile used for stations without their own
+const defaultBrandingKey = "default"
+
+// defaultPermission is granted to the user password when a report type has no Protection settings
+const defaultPermission = "print"
+
 var (
 	defs = &defaults{}
 )
 
+// Version is the application version shown on reports, set at build time with
+// -ldflags "-X github.com/pulpfree/gsales-pdf-reports/config.Version=..."
+var Version = "dev"
+
 // Load method
 func (c *Config) Load() (err error) {
 
@@ -62,7 +75,7 @@ func (c *Config) Load() (err error) {
 	c.setDBConnectURL()
 	c.setFinal()
 
-	return err
+	return c.setTimeZone()
 }
 
 // GetStageEnv method
@@ -75,6 +88,20 @@ func (c *Config) GetMongoConnectURL() string {
 	return c.DBConnectURL
 }
 
+// GetBranding method returns the branding profile configured for stationID, merged over the default profile
+func (c *Config) GetBranding(stationID string) *model.Branding {
+	return model.MergeBranding(c.Branding[stationID], c.Branding[defaultBrandingKey])
+}
+
+// GetProtection method returns the PDF encryption settings for the report type, by default
+// encryption is only applied when a password is requested and the user may print but not copy or modify
+func (c *Config) GetProtection(rt model.ReportType) *Protection {
+	if p, ok := c.Protection[rt.String()]; ok && p != nil {
+		return p
+	}
+	return &Protection{Permissions: []string{defaultPermission}}
+}
+
 // this must be called first in c.Load
 func (c *Config) setDefaults() (err error) {
 
@@ -131,6 +158,9 @@ func (c *Config) setEnvVars() (err error) {
 	vals := reflect.Indirect(reflect.ValueOf(defs))
 	for i := 0; i < vals.NumField(); i++ {
 		nm := vals.Type().Field(i).Name
+		if vals.Field(i).Kind() != reflect.String {
+			continue
+		}
 		if e := os.Getenv(nm); e != "" {
 			vals.Field(i).SetString(e)
 		}
@@ -178,7 +208,7 @@ func (c *Config) setSSMParams() (err error) {
 	for _, r := range res.Parameters {
 		paramName := strings.Split(*r.Name, "/")[3]
 		structKey := t.FieldByName(paramName)
-		if structKey.IsValid() {
+		if structKey.IsValid() && structKey.Kind() == reflect.String {
 			structKey.Set(reflect.ValueOf(*r.Value))
 		}
 	}
@@ -201,9 +231,25 @@ func (c *Config) setAWSConnectURL() {
 	c.DBConnectURL = fmt.Sprintf("mongodb+srv://%s/%s?authSource=%sexternal&authMechanism=MONGODB-AWS&retryWrites=true&w=majority", defs.DBHost, defs.DBName, "$")
 }
 
+// setTimeZone method loads the zone report timestamps are shown in, UTC when not set
+func (c *Config) setTimeZone() (err error) {
+	c.TimeZone, err = time.LoadLocation(defs.TimeZone)
+	if err != nil {
+		return fmt.Errorf("Invalid TimeZone: %s", err.Error())
+	}
+	return nil
+}
+
 // Copies required fields from the defaults to the Config struct
 func (c *Config) setFinal() {
 	c.AWSRegion = defs.AWSRegion
+	c.Authorization = defs.Authorization
+	c.Branding = defs.Branding
 	c.DBName = defs.DBName
+	c.OwnerPassword = defs.OwnerPassword
+	c.Protection = defs.Protection
 	c.S3Bucket = defs.S3Bucket
+	c.SigningCert = defs.SigningCert
+	c.SigningKey = defs.SigningKey
+	c.VerifyURL = defs.VerifyURL
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/pulpfree/gsales-pdf-reports/model"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -75,6 +76,19 @@ func (suite *IntegSuite) TestSetFinal() {
 	suite.IsType(se, suite.cfg.Stage)
 }
 
+// TestGetBranding method
+func (suite *IntegSuite) TestGetBranding() {
+	suite.cfg.setFinal()
+	suite.cfg.Branding["56cf1815982d82b0f3000001"] = &model.Branding{Logo: "franchise-logo.png"}
+
+	b := suite.cfg.GetBranding("56cf1815982d82b0f3000001")
+	suite.Equal("franchise-logo.png", b.Logo)
+	suite.Equal(defs.Branding["default"].Link, b.Link)
+
+	b = suite.cfg.GetBranding("56cf1815982d82b0f3000002")
+	suite.Equal(defs.Branding["default"].Logo, b.Logo)
+}
+
 // TestIntegrationSuite function
 func TestIntegrationSuite(t *testing.T) {
 	suite.Run(t, new(IntegSuite))
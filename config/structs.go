@@ -1,19 +1,59 @@
 package config
 
+import (
+	"time"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
 // defaults struct
 type defaults struct {
-	AWSRegion string `yaml:"AWSRegion"`
-	DBHost    string `yaml:"DBHost"`
-	DBName    string `yaml:"DBName"`
-	S3Bucket  string `yaml:"S3Bucket"`
-	SsmPath   string `yaml:"SsmPath"`
-	Stage     string `yaml:"Stage"`
+	AWSRegion     string                     `yaml:"AWSRegion"`
+	Authorization *Authorization             `yaml:"Authorization"`
+	Branding      map[string]*model.Branding `yaml:"Branding"`
+	DBHost        string                     `yaml:"DBHost"`
+	DBName        string                     `yaml:"DBName"`
+	OwnerPassword string                     `yaml:"OwnerPassword"`
+	Protection    map[string]*Protection     `yaml:"Protection"`
+	S3Bucket      string                     `yaml:"S3Bucket"`
+	SigningCert   string                     `yaml:"SigningCert"`
+	SigningKey    string                     `yaml:"SigningKey"`
+	SsmPath       string                     `yaml:"SsmPath"`
+	Stage         string                     `yaml:"Stage"`
+	TimeZone      string                     `yaml:"TimeZone"`
+	VerifyURL     string                     `yaml:"VerifyURL"`
 }
 
 type config struct {
-	AWSRegion    string
-	DBConnectURL string
-	DBName       string
-	S3Bucket     string
-	Stage        StageEnvironment
+	AWSRegion     string
+	Authorization *Authorization
+	Branding      map[string]*model.Branding
+	DBConnectURL  string
+	DBName        string
+	OwnerPassword string
+	Protection    map[string]*Protection
+	S3Bucket      string
+	SigningCert   string
+	SigningKey    string
+	Stage         StageEnvironment
+	TimeZone      *time.Location
+	VerifyURL     string
+}
+
+// Protection struct holds the PDF encryption settings for a report type
+type Protection struct {
+	Permissions []string `yaml:"Permissions"`
+	Required    bool     `yaml:"Required"`
+}
+
+// Authorization struct maps Cognito groups to the stations and report types their members may request
+type Authorization struct {
+	AdminGroups []string                `yaml:"AdminGroups"`
+	Groups      map[string]*GroupAccess `yaml:"Groups"`
+}
+
+// GroupAccess struct lists the station IDs and report types a group may request, "*" allows any
+type GroupAccess struct {
+	ReportTypes []string `yaml:"ReportTypes"`
+	Stations    []string `yaml:"Stations"`
 }
@@ -1,10 +1,11 @@
 module github.com/pulpfree/gsales-pdf-reports
 
-go 1.15
+go 1.16
 
 require (
 	github.com/aws/aws-lambda-go v1.19.1
 	github.com/aws/aws-sdk-go v1.34.19
+	github.com/boombuler/barcode v1.0.1
 	github.com/google/go-cmp v0.5.2 // indirect
 	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/pulpfree/go-errors v1.0.1
@@ -15,6 +16,7 @@ require (
 	github.com/tidwall/pretty v1.0.2 // indirect
 	github.com/xdg/stringprep v1.0.0 // indirect
 	go.mongodb.org/mongo-driver v1.4.1
+	go.mozilla.org/pkcs7 v0.10.0
 	golang.org/x/crypto v0.0.0-20200820211705-5c72a883971a // indirect
 	golang.org/x/sys v0.0.0-20200909081042-eff7692f9009 // indirect
 	gopkg.in/yaml.v2 v2.3.0
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	pkgerrors "github.com/pulpfree/go-errors"
 	pres "github.com/pulpfree/lambda-go-proxy-response"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/auth"
 	"github.com/pulpfree/gsales-pdf-reports/config"
 	"github.com/pulpfree/gsales-pdf-reports/model"
 	"github.com/pulpfree/gsales-pdf-reports/report"
@@ -61,29 +64,36 @@ func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	}
 
 	var r *model.RequestInput
-	json.Unmarshal([]byte(req.Body), &r)
+	if err := json.Unmarshal([]byte(req.Body), &r); err != nil {
+		err = apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: err.Error(), Caller: "handler.HandleRequest", Msg: "Error invalid request body"})
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
 
 	// validate input
 	reportRequest, err := validate.SetRequest(r)
 	if err != nil {
 		fmt.Printf("err in validate %+v\n", err)
-		return pres.ProxyRes(pres.Response{
-			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
 	}
 
+	reportRequest.Principal = auth.GetPrincipal(req)
+	log.WithFields(log.Fields{
+		"sub":         reportRequest.Principal.GetSub(),
+		"user":        reportRequest.Principal.Name(),
+		"stationID":   reportRequest.StationID.Hex(),
+		"stationName": reportRequest.StationName,
+		"type":        r.ReportType,
+	}).Info("report requested")
+
+	// the principal is authorized by report.New, once a station requested by name is resolved to its id
 	rpt, err := report.New(reportRequest, cfg)
 	if err != nil {
-		return pres.ProxyRes(pres.Response{
-			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
 	}
 
 	url, err := rpt.CreateSignedURL()
 	if err != nil {
-		return pres.ProxyRes(pres.Response{
-			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
 	}
 
 	urlStr := url[0:100]
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"time"
+
+	pres "github.com/pulpfree/lambda-go-proxy-response"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// HandleRequest function describes every supported report type and its request parameters
+// ie: GET /reports/types
+func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+
+	hdrs := make(map[string]string)
+	hdrs["Content-Type"] = "application/json"
+	hdrs["Access-Control-Allow-Origin"] = "*"
+	hdrs["Access-Control-Allow-Methods"] = "GET,OPTIONS"
+	hdrs["Access-Control-Allow-Headers"] = "Authorization,Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token"
+
+	if req.HTTPMethod == "OPTIONS" {
+		return events.APIGatewayProxyResponse{Body: string("null"), Headers: hdrs, StatusCode: 200}, nil
+	}
+
+	return pres.ProxyRes(pres.Response{
+		Code:      200,
+		Data:      model.ReportTypes(),
+		Status:    "success",
+		Timestamp: time.Now().Unix(),
+	}, hdrs, nil), nil
+}
+
+func main() {
+	lambda.Start(HandleRequest)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"time"
+
+	pres "github.com/pulpfree/lambda-go-proxy-response"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/auth"
+	"github.com/pulpfree/gsales-pdf-reports/config"
+	"github.com/pulpfree/gsales-pdf-reports/model/db"
+	"github.com/pulpfree/gsales-pdf-reports/validate"
+)
+
+var (
+	cfg *config.Config
+)
+
+func init() {
+	cfg = &config.Config{}
+	err := cfg.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// HandleRequest function lists a station's shifts for a day
+// ie: GET /stations/56cf1815982d82b0f3000001/shifts?date=2019-12-21
+func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+
+	hdrs := make(map[string]string)
+	hdrs["Content-Type"] = "application/json"
+	hdrs["Access-Control-Allow-Origin"] = "*"
+	hdrs["Access-Control-Allow-Methods"] = "GET,OPTIONS"
+	hdrs["Access-Control-Allow-Headers"] = "Authorization,Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token"
+
+	if req.HTTPMethod == "OPTIONS" {
+		return events.APIGatewayProxyResponse{Body: string("null"), Headers: hdrs, StatusCode: 200}, nil
+	}
+
+	t := time.Now()
+
+	listRequest, err := validate.SetShiftListRequest(req.PathParameters["id"], req.QueryStringParameters["date"])
+	if err != nil {
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
+
+	// authorize before any records are read
+	principal := auth.GetPrincipal(req)
+	if err = auth.AuthorizeShiftList(principal, listRequest.StationID, cfg); err != nil {
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
+
+	mdb, err := db.NewDB(cfg.GetMongoConnectURL(), cfg.DBName)
+	if err != nil {
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
+	defer mdb.Close()
+
+	shifts, err := mdb.GetShiftList(listRequest.Date, listRequest.StationID)
+	if err != nil {
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
+
+	log.WithFields(log.Fields{
+		"sub":       principal.GetSub(),
+		"user":      principal.Name(),
+		"stationID": listRequest.StationID.Hex(),
+	}).Infof("listed %d shifts", len(shifts))
+
+	return pres.ProxyRes(pres.Response{
+		Code:      200,
+		Data:      shifts,
+		Status:    "success",
+		Timestamp: t.Unix(),
+	}, hdrs, nil), nil
+}
+
+func main() {
+	lambda.Start(HandleRequest)
+}
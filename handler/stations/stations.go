@@ -0,0 +1,81 @@
+package main
+
+import (
+	"time"
+
+	pres "github.com/pulpfree/lambda-go-proxy-response"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/auth"
+	"github.com/pulpfree/gsales-pdf-reports/config"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"github.com/pulpfree/gsales-pdf-reports/model/db"
+)
+
+var (
+	cfg *config.Config
+)
+
+func init() {
+	cfg = &config.Config{}
+	err := cfg.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// HandleRequest function lists the stations the requesting user may access
+// ie: GET /stations
+func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+
+	hdrs := make(map[string]string)
+	hdrs["Content-Type"] = "application/json"
+	hdrs["Access-Control-Allow-Origin"] = "*"
+	hdrs["Access-Control-Allow-Methods"] = "GET,OPTIONS"
+	hdrs["Access-Control-Allow-Headers"] = "Authorization,Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token"
+
+	if req.HTTPMethod == "OPTIONS" {
+		return events.APIGatewayProxyResponse{Body: string("null"), Headers: hdrs, StatusCode: 200}, nil
+	}
+
+	t := time.Now()
+	principal := auth.GetPrincipal(req)
+
+	mdb, err := db.NewDB(cfg.GetMongoConnectURL(), cfg.DBName)
+	if err != nil {
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
+	defer mdb.Close()
+
+	stations, err := mdb.GetStations()
+	if err != nil {
+		return apierr.ProxyRes(err, hdrs, t.Unix()), nil
+	}
+
+	// only the stations the user may request reports for are listed
+	allowed := []*model.Station{}
+	for _, st := range stations {
+		if auth.StationAccess(principal, st.ID, cfg) {
+			allowed = append(allowed, st)
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"sub":  principal.GetSub(),
+		"user": principal.Name(),
+	}).Infof("listed %d of %d stations", len(allowed), len(stations))
+
+	return pres.ProxyRes(pres.Response{
+		Code:      200,
+		Data:      allowed,
+		Status:    "success",
+		Timestamp: t.Unix(),
+	}, hdrs, nil), nil
+}
+
+func main() {
+	lambda.Start(HandleRequest)
+}
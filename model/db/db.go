@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,6 +15,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
 	"github.com/pulpfree/gsales-pdf-reports/model"
 )
 
@@ -25,6 +28,7 @@ type MDB struct {
 
 // DB and Table constants
 const (
+	colAudits       = "report-audits"
 	colConfig       = "config"
 	colEmployees    = "employees"
 	colJournals     = "journals"
@@ -78,15 +82,27 @@ func (db *MDB) Close() {
 	log.Infoln("Connection to MongoDB closed.")
 }
 
+// GetAudits method returns the most recent report generations for the station, newest first
+func (db *MDB) GetAudits(stationID primitive.ObjectID, limit int64) (audits []*model.ReportAudit, err error) {
+
+	audits, err = db.fetchAudits(stationID, limit)
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to fetch audit records with stationID:%v", stationID)
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetAudits", Msg: "Failed to fetch report audits"})
+	}
+
+	return audits, err
+}
+
 // GetDay method
 func (db *MDB) GetDay(date time.Time, stationID primitive.ObjectID) (day bson.M, err error) {
 
 	day, err = db.fetchDay(date, stationID)
 	if err != nil {
-		return nil, err
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "db.GetDay", Msg: "Failed to fetch day"})
 	}
 	if day == nil {
-		return nil, &pkgerrors.StdError{Err: "", Caller: "db.GetDay", Msg: noRecordsMsg}
+		return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: "", Caller: "db.GetDay", Msg: noRecordsMsg})
 	}
 
 	return day, err
@@ -98,7 +114,7 @@ func (db *MDB) GetEmployee(attendantID primitive.ObjectID) (employee *model.Empl
 	employee, err = db.fetchEmployee(attendantID)
 	if err != nil {
 		errStr := fmt.Sprintf("Failed to fetch employee record with id:%s", attendantID)
-		return nil, &pkgerrors.StdError{Err: errStr, Caller: "db.GetShift", Msg: "Failed to fetch employee"}
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetShift", Msg: "Failed to fetch employee"})
 	}
 
 	return employee, err
@@ -110,7 +126,7 @@ func (db *MDB) GetJournals(recordNum string, stationID primitive.ObjectID) (jour
 	journals, err = db.fetchJournals(recordNum, stationID)
 	if err != nil {
 		errStr := fmt.Sprintf("Failed to fetch journal records with recordNum:%s and stationID:%v", recordNum, stationID)
-		return nil, &pkgerrors.StdError{Err: errStr, Caller: "db.GetJournals", Msg: "Failed to fetch journal entries"}
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetJournals", Msg: "Failed to fetch journal entries"})
 	}
 
 	return journals, err
@@ -120,30 +136,171 @@ func (db *MDB) GetJournals(recordNum string, stationID primitive.ObjectID) (jour
 func (db *MDB) GetShift(recordNum string, stationID primitive.ObjectID) (shift *model.Sales, err error) {
 
 	shift, err = db.fetchShift(recordNum, stationID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: "", Caller: "db.GetShift", Msg: noRecordsMsg})
+	}
 	if err != nil {
-		return nil, err
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "db.GetShift", Msg: "Failed to fetch shift"})
 	}
 	if shift == nil {
-		return nil, &pkgerrors.StdError{Err: "", Caller: "db.GetShift", Msg: noRecordsMsg}
+		return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: "", Caller: "db.GetShift", Msg: noRecordsMsg})
 	}
 
 	return shift, err
 }
 
+// GetShifts method
+func (db *MDB) GetShifts(date time.Time, stationID primitive.ObjectID) (shifts []*model.Sales, err error) {
+
+	shifts, err = db.fetchShifts(date, stationID)
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to fetch shift records with date:%s and stationID:%v", date.Format("2006-01-02"), stationID)
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetShifts", Msg: "Failed to fetch shifts"})
+	}
+	if len(shifts) == 0 {
+		return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: "", Caller: "db.GetShifts", Msg: noRecordsMsg})
+	}
+
+	return shifts, err
+}
+
+// GetShiftList method summarizes each of the station's shifts for the day, a day without shifts is an empty list
+func (db *MDB) GetShiftList(date time.Time, stationID primitive.ObjectID) (list []*model.ShiftListItem, err error) {
+
+	shifts, err := db.fetchShifts(date, stationID)
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to fetch shift records with date:%s and stationID:%v", date.Format("2006-01-02"), stationID)
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetShiftList", Msg: "Failed to fetch shifts"})
+	}
+
+	var ids []primitive.ObjectID
+	for _, shift := range shifts {
+		if shift.Attendant != nil {
+			ids = append(ids, shift.Attendant.ID)
+		}
+	}
+	employees, err := db.fetchEmployees(ids)
+	if err != nil {
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "db.GetShiftList", Msg: "Failed to fetch employees"})
+	}
+
+	list = []*model.ShiftListItem{}
+	for _, shift := range shifts {
+		item := &model.ShiftListItem{RecordNumber: shift.RecordNum}
+		if shift.Attendant != nil {
+			if e, ok := employees[shift.Attendant.ID]; ok {
+				item.AttendantName = fmt.Sprintf("%s, %s", e.NameLast, e.NameFirst)
+			}
+			item.OvershortComplete = shift.Attendant.OvershortComplete
+			item.SheetComplete = shift.Attendant.SheetComplete
+		}
+		if shift.Overshort != nil {
+			item.OvershortAmount = shift.Overshort.Amount
+		}
+		if shift.Summary != nil {
+			item.FuelSales = shift.Summary.FuelDollar
+			item.NonFuelSales = shift.Summary.TotalNonFuel
+			item.TotalSales = shift.Summary.TotalSales
+		}
+		list = append(list, item)
+	}
+
+	return list, err
+}
+
 // GetStation method
 func (db *MDB) GetStation(stationID primitive.ObjectID) (station *model.Station, err error) {
 
 	station, err = db.fetchStation(stationID)
 	if err != nil {
 		errStr := fmt.Sprintf("Failed to fetch station record with id:%s", stationID)
-		return nil, &pkgerrors.StdError{Err: errStr, Caller: "db.GetShift", Msg: "Failed to fetch station"}
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetShift", Msg: "Failed to fetch station"})
+	}
+
+	return station, err
+}
+
+// GetStationByName method finds a station by its name, ignoring case
+func (db *MDB) GetStationByName(name string) (station *model.Station, err error) {
+
+	station, err = db.fetchStationByName(name)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		errStr := fmt.Sprintf("No station record with name:%s", name)
+		return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: errStr, Caller: "db.GetStationByName", Msg: fmt.Sprintf("Station %s not found", name)})
+	}
+	if err != nil {
+		errStr := fmt.Sprintf("Failed to fetch station record with name:%s", name)
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: errStr, Caller: "db.GetStationByName", Msg: "Failed to fetch station"})
 	}
 
 	return station, err
 }
 
+// GetStations method returns every station ordered by name
+func (db *MDB) GetStations() (stations []*model.Station, err error) {
+
+	stations, err = db.fetchStations()
+	if err != nil {
+		return nil, apierr.New(errorCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "db.GetStations", Msg: "Failed to fetch stations"})
+	}
+
+	return stations, err
+}
+
+// InsertAudit method
+func (db *MDB) InsertAudit(audit *model.ReportAudit) (err error) {
+
+	col := db.db.Collection(colAudits)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := col.InsertOne(ctx, audit)
+	if err != nil {
+		return apierr.New(errorCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "db.InsertAudit", Msg: "Failed to record report audit"})
+	}
+	audit.ID, _ = res.InsertedID.(primitive.ObjectID)
+
+	return nil
+}
+
 // ======================== Un-exported Methods ================================================ //
 
+// errorCode function categorizes a driver error, a missing document is not found and timeouts
+// are upstream timeouts
+func errorCode(err error) apierr.Code {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return apierr.CodeNotFound
+	}
+	if apierr.IsTimeout(err) {
+		return apierr.CodeUpstreamTimeout
+	}
+	return apierr.CodeInternal
+}
+
+// fetchAudits method
+func (db *MDB) fetchAudits(stationID primitive.ObjectID, limit int64) (audits []*model.ReportAudit, err error) {
+
+	col := db.db.Collection(colAudits)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "createdAt", Value: -1}})
+	findOptions.SetLimit(limit)
+	filter := bson.D{primitive.E{Key: "stationID", Value: stationID}}
+	cur, err := col.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	audits = []*model.ReportAudit{}
+	if err := cur.All(ctx, &audits); err != nil {
+		return nil, err
+	}
+
+	return audits, err
+}
+
 // fetchDay method
 func (db *MDB) fetchDay(date time.Time, stationID primitive.ObjectID) (day bson.M, err error) {
 
@@ -536,6 +693,35 @@ func (db *MDB) fetchEmployee(attendantID primitive.ObjectID) (employee *model.Em
 	return employee, err
 }
 
+// fetchEmployees method returns the employees with the given ids keyed by id
+func (db *MDB) fetchEmployees(ids []primitive.ObjectID) (employees map[primitive.ObjectID]*model.Employee, err error) {
+
+	employees = make(map[primitive.ObjectID]*model.Employee)
+	if len(ids) == 0 {
+		return employees, nil
+	}
+
+	col := db.db.Collection(colEmployees)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.D{primitive.E{Key: "_id", Value: bson.D{primitive.E{Key: "$in", Value: ids}}}}
+	cur, err := col.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*model.Employee
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	for _, e := range results {
+		employees[e.ID] = e
+	}
+
+	return employees, err
+}
+
 // fetchJournals method
 func (db *MDB) fetchJournals(recordNum string, stationID primitive.ObjectID) (journals []*model.Journal, err error) {
 
@@ -575,6 +761,31 @@ func (db *MDB) fetchShift(recordNum string, stationID primitive.ObjectID) (shift
 	return shift, err
 }
 
+// fetchShifts method
+func (db *MDB) fetchShifts(date time.Time, stationID primitive.ObjectID) (shifts []*model.Sales, err error) {
+
+	col := db.db.Collection(colSales)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "recordNum", Value: 1}})
+	filter := bson.D{
+		primitive.E{Key: "recordDate", Value: date},
+		primitive.E{Key: "stationID", Value: stationID},
+	}
+	cur, err := col.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cur.All(ctx, &shifts); err != nil {
+		return nil, err
+	}
+
+	return shifts, err
+}
+
 // fetchStation method
 func (db *MDB) fetchStation(stationID primitive.ObjectID) (station *model.Station, err error) {
 
@@ -587,3 +798,39 @@ func (db *MDB) fetchStation(stationID primitive.ObjectID) (station *model.Statio
 
 	return station, err
 }
+
+// fetchStationByName method
+func (db *MDB) fetchStationByName(name string) (station *model.Station, err error) {
+
+	col := db.db.Collection(colStations)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pattern := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"}
+	filter := bson.D{primitive.E{Key: "name", Value: pattern}}
+	err = col.FindOne(ctx, filter).Decode(&station)
+
+	return station, err
+}
+
+// fetchStations method
+func (db *MDB) fetchStations() (stations []*model.Station, err error) {
+
+	col := db.db.Collection(colStations)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{primitive.E{Key: "name", Value: 1}})
+	cur, err := col.Find(ctx, bson.D{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	stations = []*model.Station{}
+	if err := cur.All(ctx, &stations); err != nil {
+		return nil, err
+	}
+
+	return stations, err
+}
@@ -117,6 +117,35 @@ func (s *IntegSuite) TestGetShift() {
 	s.Error(err)
 }
 
+// TestGetShiftList method
+func (s *IntegSuite) TestGetShiftList() {
+	dte, _ := time.Parse(timeForm, date)
+	list, err := s.db.GetShiftList(dte, s.stationID)
+	s.NoError(err)
+	s.True(len(list) > 0)
+	s.NotEqual("", list[0].AttendantName)
+
+	futureDate := "2202-02-02"
+	dte, _ = time.Parse(timeForm, futureDate)
+	list, err = s.db.GetShiftList(dte, s.stationID)
+	s.NoError(err)
+	s.Len(list, 0)
+}
+
+// TestGetStations method
+func (s *IntegSuite) TestGetStations() {
+	stations, err := s.db.GetStations()
+	s.NoError(err)
+	s.True(len(stations) > 0)
+
+	station, err := s.db.GetStationByName(stations[0].Name)
+	s.NoError(err)
+	s.Equal(stations[0].ID, station.ID)
+
+	_, err = s.db.GetStationByName("no such station")
+	s.Error(err)
+}
+
 // TestIntegrationSuite function
 func TestIntegrationSuite(t *testing.T) {
 	suite.Run(t, new(IntegSuite))
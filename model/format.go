@@ -0,0 +1,29 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// Output format constants
+const (
+	FormatArchive  = "archive"
+	FormatStandard = "standard"
+)
+
+// FormatStringToFormat function normalizes a requested output format, ie: "Archive" returns FormatArchive
+// an empty format returns FormatStandard
+//
+// FormatArchive follows the PDF/A rules gofpdf can meet, embedded fonts, XMP metadata and no links,
+// transparency or encryption, but has no output intent so it is not PDF/A and isn't named as such
+func FormatStringToFormat(format string) (string, error) {
+
+	switch strings.ToLower(format) {
+	case "", FormatStandard:
+		return FormatStandard, nil
+	case FormatArchive:
+		return FormatArchive, nil
+	}
+
+	return "", errors.New("Invalid format request")
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// Locale constants
+const (
+	LocaleEnCA = "en-CA"
+	LocaleFrCA = "fr-CA"
+)
+
+// DefaultLocale is used when a request does not specify one
+const DefaultLocale = LocaleEnCA
+
+// LocaleStringToLocale function normalizes a requested locale, ie: "fr", "fr_ca" or "FR-CA" all return fr-CA
+func LocaleStringToLocale(loc string) (string, error) {
+
+	if loc == "" {
+		return DefaultLocale, nil
+	}
+
+	switch strings.ToLower(strings.Replace(loc, "_", "-", -1)) {
+	case "en", "en-ca":
+		return LocaleEnCA, nil
+	case "fr", "fr-ca":
+		return LocaleFrCA, nil
+	}
+
+	return "", errors.New("Invalid locale request")
+}
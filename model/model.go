@@ -14,7 +14,13 @@ type DBHandler interface {
 	GetEmployee(primitive.ObjectID) (*Employee, error)
 	GetJournals(string, primitive.ObjectID) ([]*Journal, error)
 	GetShift(string, primitive.ObjectID) (*Sales, error)
+	GetShifts(time.Time, primitive.ObjectID) ([]*Sales, error)
+	GetShiftList(time.Time, primitive.ObjectID) ([]*ShiftListItem, error)
+	GetAudits(primitive.ObjectID, int64) ([]*ReportAudit, error)
 	GetStation(primitive.ObjectID) (*Station, error)
+	GetStationByName(string) (*Station, error)
+	GetStations() ([]*Station, error)
+	InsertAudit(*ReportAudit) error
 }
 
 // Record interface
@@ -64,3 +70,35 @@ func SetString(s interface{}) string {
 
 	return ret
 }
+
+// MergeBranding function returns a copy of profile with any empty field taken from base
+func MergeBranding(profile, base *Branding) *Branding {
+	if profile == nil {
+		profile = &Branding{}
+	}
+	if base == nil {
+		base = &Branding{}
+	}
+
+	ret := *profile
+	if len(ret.Address) == 0 {
+		ret.Address = base.Address
+	}
+	if len(ret.FillColor) == 0 {
+		ret.FillColor = base.FillColor
+	}
+	if ret.FooterText == "" {
+		ret.FooterText = base.FooterText
+	}
+	if len(ret.HeaderColor) == 0 {
+		ret.HeaderColor = base.HeaderColor
+	}
+	if ret.Link == "" {
+		ret.Link = base.Link
+	}
+	if ret.Logo == "" {
+		ret.Logo = base.Logo
+	}
+
+	return &ret
+}
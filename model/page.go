@@ -0,0 +1,50 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// Page orientation constants, as used by gofpdf
+const (
+	OrientationLandscape = "L"
+	OrientationPortrait  = "P"
+)
+
+// Page size constants, as used by gofpdf
+const (
+	PageSizeA4     = "A4"
+	PageSizeLetter = "Letter"
+)
+
+// OrientationStringToOrientation function normalizes a requested orientation, ie: "landscape" or "L"
+// an empty orientation is returned as is so the report layout default applies
+func OrientationStringToOrientation(orientation string) (string, error) {
+
+	switch strings.ToLower(orientation) {
+	case "":
+		return "", nil
+	case "p", "portrait":
+		return OrientationPortrait, nil
+	case "l", "landscape":
+		return OrientationLandscape, nil
+	}
+
+	return "", errors.New("Invalid orientation request")
+}
+
+// PageSizeStringToPageSize function normalizes a requested page size, ie: "a4" or "letter"
+// an empty size is returned as is so the report layout default applies
+func PageSizeStringToPageSize(size string) (string, error) {
+
+	switch strings.ToLower(size) {
+	case "":
+		return "", nil
+	case "a4":
+		return PageSizeA4, nil
+	case "letter":
+		return PageSizeLetter, nil
+	}
+
+	return "", errors.New("Invalid page size request")
+}
@@ -0,0 +1,44 @@
+package model
+
+// Principal struct identifies the authenticated user making a request, from the Cognito authorizer claims
+type Principal struct {
+	Email    string   `bson:"email" json:"email"`
+	Groups   []string `bson:"groups" json:"groups"`
+	Sub      string   `bson:"sub" json:"sub"`
+	Username string   `bson:"username" json:"username"`
+}
+
+// Name method returns the most readable identifier available for the principal
+func (p *Principal) Name() string {
+	if p == nil {
+		return ""
+	}
+	switch {
+	case p.Email != "":
+		return p.Email
+	case p.Username != "":
+		return p.Username
+	}
+	return p.Sub
+}
+
+// GetSub method returns the Cognito subject, the stable user id, or an empty string for a nil principal
+func (p *Principal) GetSub() string {
+	if p == nil {
+		return ""
+	}
+	return p.Sub
+}
+
+// InGroup method reports whether the principal belongs to group
+func (p *Principal) InGroup(group string) bool {
+	if p == nil {
+		return false
+	}
+	for _, g := range p.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// recordDateLen is the length of the YYYY-MM-DD date part of a record number
+const recordDateLen = 10
+
+// RecordNumberDate function returns the calendar date of a record number, ie: "2019-12-21-2" returns 2019-12-21
+func RecordNumberDate(recordNumber string) (time.Time, error) {
+	if len(recordNumber) < recordDateLen {
+		return time.Time{}, errors.New("Invalid record number date")
+	}
+	return time.Parse("2006-01-02", recordNumber[:recordDateLen])
+}
+
+// RecordNumberShift function returns the shift suffix of a record number, ie: "2019-12-21-2" returns "-2"
+func RecordNumberShift(recordNumber string) string {
+	if i := strings.LastIndex(recordNumber, "-"); i >= recordDateLen {
+		return recordNumber[i:]
+	}
+	return ""
+}
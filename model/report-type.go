@@ -1,6 +1,9 @@
 package model
 
-import "errors"
+import (
+	"errors"
+	"sort"
+)
 
 // ReportType int
 type ReportType int
@@ -11,20 +14,117 @@ const (
 	ShiftReport
 )
 
+// ReportDefinition struct describes a report type and its request parameters for the report catalog
+type ReportDefinition struct {
+	Description string            `json:"description"`
+	Example     map[string]string `json:"example"`  // Example request payload
+	Formats     []string          `json:"formats"`  // Supported output formats, defaults to every format
+	Name        string            `json:"type"`     // Request name, ie: "day"
+	Optional    []string          `json:"optional"` // Optional request parameters, in addition to those of every report
+	Required    []string          `json:"required"` // Required request parameters, in addition to those of every report
+	Title       string            `json:"title"`
+	Type        ReportType        `json:"-"`
+}
+
+// Request parameters of every report type, a station may be identified by name in place of stationID
+var (
+	commonOptional = []string{"format", "locale", "orientation", "pageSize", "password", "stationName"}
+	commonRequired = []string{"stationID"}
+)
+
+// reportTypes is the registry of report definitions keyed by request name
+var reportTypes = map[string]*ReportDefinition{}
+
+func init() {
+	RegisterReportType(&ReportDefinition{
+		Description: "Sales, fuel and cash summary of every shift for a station on a single day",
+		Example:     map[string]string{"type": "day", "date": "2019-12-21", "stationID": "56cf1815982d82b0f3000001"},
+		Name:        "day",
+		Required:    []string{"date"},
+		Title:       "Day Report",
+		Type:        DayReport,
+	})
+	RegisterReportType(&ReportDefinition{
+		Description: "Attendant, sales, cash and card details of a single shift",
+		Example:     map[string]string{"type": "shift", "recordNumber": "2019-12-21-2", "stationID": "56cf1815982d82b0f3000001"},
+		Name:        "shift",
+		Optional:    []string{"date"},
+		Required:    []string{"recordNumber"},
+		Title:       "Shift Report",
+		Type:        ShiftReport,
+	})
+}
+
+// RegisterReportType function adds a report type to the registry, its Required and Optional parameters
+// are the schema validate checks requests against, the parameters of every report
+// are added to its own and an empty Formats lists every output format
+// registering a name or type twice panics, as with a duplicate init
+func RegisterReportType(def *ReportDefinition) {
+	if def.Name == "" || def.Type == 0 {
+		panic("model: report type requires a name and type")
+	}
+	for _, d := range reportTypes {
+		if d.Name == def.Name || d.Type == def.Type {
+			panic("model: report type registered twice: " + def.Name)
+		}
+	}
+
+	d := *def
+	d.Optional = append(append([]string{}, def.Optional...), commonOptional...)
+	d.Required = append(append([]string{}, def.Required...), commonRequired...)
+	if len(d.Formats) == 0 {
+		d.Formats = []string{FormatStandard, FormatArchive}
+	}
+	reportTypes[d.Name] = &d
+}
+
+// ReportTypes function returns a copy of the definition of every registered report type, ordered by type
+func ReportTypes() []ReportDefinition {
+	defs := make([]ReportDefinition, 0, len(reportTypes))
+	for _, d := range reportTypes {
+		defs = append(defs, d.copy())
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Type < defs[j].Type })
+	return defs
+}
+
+// LookupReportType function returns a copy of the definition registered for rt
+func LookupReportType(rt ReportType) (ReportDefinition, bool) {
+	for _, d := range reportTypes {
+		if d.Type == rt {
+			return d.copy(), true
+		}
+	}
+	return ReportDefinition{}, false
+}
+
 // ReportStringToType function
 func ReportStringToType(rType string) (ReportType, error) {
-	var rt ReportType
+	if d, ok := reportTypes[rType]; ok {
+		return d.Type, nil
+	}
+	return 0, errors.New("Invalid report type request")
+}
 
-	switch rType {
-	case "day":
-		rt = DayReport
-	case "shift":
-		rt = ShiftReport
-	default:
-		rt = 0
+// String method returns the request name of the report type, ie: "day"
+func (rt ReportType) String() string {
+	for _, d := range reportTypes {
+		if d.Type == rt {
+			return d.Name
+		}
 	}
-	if rt == 0 {
-		return rt, errors.New("Invalid report type request")
+	return ""
+}
+
+// copy method returns a deep copy of the definition so callers can't modify the registry
+func (d *ReportDefinition) copy() ReportDefinition {
+	c := *d
+	c.Formats = append([]string{}, d.Formats...)
+	c.Optional = append([]string{}, d.Optional...)
+	c.Required = append([]string{}, d.Required...)
+	c.Example = make(map[string]string, len(d.Example))
+	for k, v := range d.Example {
+		c.Example[k] = v
 	}
-	return rt, nil
+	return c
 }
@@ -18,6 +18,16 @@ type Attendant struct {
 	Name              string             `bson:"name" json:"name"`
 }
 
+// Branding struct
+type Branding struct {
+	Address     []string `bson:"address" json:"address" yaml:"Address"`
+	FillColor   []int    `bson:"fillColor" json:"fillColor" yaml:"FillColor"`
+	FooterText  string   `bson:"footerText" json:"footerText" yaml:"FooterText"`
+	HeaderColor []int    `bson:"headerColor" json:"headerColor" yaml:"HeaderColor"`
+	Link        string   `bson:"link" json:"link" yaml:"Link"`
+	Logo        string   `bson:"logo" json:"logo" yaml:"Logo"`
+}
+
 // Cash struct
 type Cash struct {
 	Bills              *float64 `bson:"bills" json:"bills"`
@@ -88,6 +98,33 @@ type Overshort struct {
 	Descrip string  `bson:"descrip" json:"descrip"`
 }
 
+// ReportAudit struct records a single report generation, successful or not
+type ReportAudit struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	ContentHash string              `bson:"contentHash" json:"contentHash"` // SHA-256 of the output PDF
+	CreatedAt   time.Time           `bson:"createdAt" json:"createdAt"`
+	DurationMs  int64               `bson:"durationMs" json:"durationMs"`
+	Error       string              `bson:"error,omitempty" json:"error,omitempty"`
+	OutputKey   string              `bson:"outputKey" json:"outputKey"`
+	Principal   *Principal          `bson:"principal" json:"principal"`
+	Request     *ReportAuditRequest `bson:"request" json:"request"`
+	StationID   primitive.ObjectID  `bson:"stationID" json:"stationID"`
+	Success     bool                `bson:"success" json:"success"`
+}
+
+// ReportAuditRequest struct holds the request parameters of an audited report, the password is never stored
+type ReportAuditRequest struct {
+	Date         string `bson:"date,omitempty" json:"date,omitempty"`
+	Format       string `bson:"format" json:"format"`
+	Locale       string `bson:"locale" json:"locale"`
+	Orientation  string `bson:"orientation,omitempty" json:"orientation,omitempty"`
+	PageSize     string `bson:"pageSize,omitempty" json:"pageSize,omitempty"`
+	Protected    bool   `bson:"protected" json:"protected"`
+	RecordNumber string `bson:"recordNumber,omitempty" json:"recordNumber,omitempty"`
+	ReportType   string `bson:"reportType" json:"reportType"`
+	StationName  string `bson:"stationName,omitempty" json:"stationName,omitempty"` // station requested by name
+}
+
 // Sales struct
 type Sales struct {
 	Attendant        *Attendant
@@ -125,8 +162,22 @@ type SalesSummary struct {
 	TotalCreditCard        float64  `bson:"creditCardTotal" json:"totalCreditCard"`
 }
 
+// ShiftListItem struct summarizes a single shift for the shift listing
+type ShiftListItem struct {
+	AttendantName     string  `json:"attendantName"`
+	FuelSales         float64 `json:"fuelSales"`
+	NonFuelSales      float64 `json:"nonFuelSales"`
+	OvershortAmount   float64 `json:"overshortAmount"`
+	OvershortComplete bool    `json:"overshortComplete"`
+	RecordNumber      string  `json:"recordNumber"`
+	SheetComplete     bool    `json:"sheetComplete"`
+	TotalSales        float64 `json:"totalSales"`
+}
+
 // Station struct
 type Station struct {
-	ID   primitive.ObjectID `bson:"_id"`
-	Name string             `bson:"name"`
+	ID       primitive.ObjectID `bson:"_id" json:"id"`
+	Active   bool               `bson:"active" json:"active"`
+	Branding *Branding          `bson:"branding" json:"branding"`
+	Name     string             `bson:"name" json:"name"`
 }
@@ -14,9 +14,12 @@ type DayRecord struct {
 	CashFields
 	Date string
 	DaySummary
+	Discrepancies []*Discrepancy
 	FuelSummary
-	StationID   primitive.ObjectID
-	StationName string
+	ReconcileError string // set when the shifts couldn't be loaded to reconcile against
+	ShiftCount     int
+	StationID      primitive.ObjectID
+	StationName    string
 }
 
 // ShiftRecord struct
@@ -40,9 +43,9 @@ type ShiftRecord struct {
 type AttendantFields struct {
 	AttendantAdjustment string
 	AttendantName       string
-	OvershortComplete   string
+	OvershortComplete   bool
 	OvershortValue      float64
-	SheetComplete       string
+	SheetComplete       bool
 }
 
 // CashFields struct
@@ -74,10 +77,26 @@ type CardFields struct {
 // DaySummary struct
 type DaySummary struct {
 	NonFuel        float64
+	Overshort      float64
 	Total          float64
 	TotalCashCards float64
 }
 
+// Discrepancy struct
+type Discrepancy struct {
+	Difference float64
+	Field      string
+	DayValue   float64
+	ShiftValue float64
+	Unit       string // UnitDollar or UnitLitre
+}
+
+// Discrepancy units
+const (
+	UnitDollar = "dollar"
+	UnitLitre  = "litre"
+)
+
 // FuelSummary struct
 type FuelSummary struct {
 	Fuel1Dollar float64
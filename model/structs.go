@@ -6,18 +6,43 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// AuditRequest struct
+type AuditRequest struct {
+	Limit     int64
+	StationID primitive.ObjectID
+}
+
 // ReportRequest struct
 type ReportRequest struct {
 	Date         time.Time
+	Format       string
+	Locale       string
+	Orientation  string
+	PageSize     string
+	Password     string
+	Principal    *Principal
 	RecordNumber string
 	ReportType   *ReportType
 	StationID    primitive.ObjectID
+	StationName  string
 }
 
 // RequestInput struct
 type RequestInput struct {
 	Date         string `json:"date"`
+	Format       string `json:"format"`
+	Locale       string `json:"locale"`
+	Orientation  string `json:"orientation"`
+	PageSize     string `json:"pageSize"`
+	Password     string `json:"password"`
 	RecordNumber string `json:"recordNumber"`
 	ReportType   string `json:"type"`
 	StationID    string `json:"stationID"`
+	StationName  string `json:"stationName"`
+}
+
+// ShiftListRequest struct
+type ShiftListRequest struct {
+	Date      time.Time
+	StationID primitive.ObjectID
 }
@@ -0,0 +1,132 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif" // logo formats gofpdf accepts
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// defaultBranding is used for any profile field left empty
+var defaultBranding = &model.Branding{
+	FillColor:   []int{220, 220, 220},
+	HeaderColor: []int{120, 120, 120},
+	Link:        "http://www.gales.ca",
+	Logo:        "logo.png",
+}
+
+// setLetterhead method draws the branded logo, the report title, the info lines and the address block
+func (p *PDF) setLetterhead(title string, info ...string) {
+	pdf := p.file
+	b := p.branding
+
+	// archive output must be self contained and opaque, so the logo only links back to the website
+	// and keeps its transparency otherwise
+	logo := p.logoFile()
+	link := b.Link
+	if p.archive {
+		logo = p.opaqueImage(logo)
+		link = ""
+	}
+
+	pdf.SetFont(fontFamily, "", 12)
+	p.setFillColor()
+	pdf.Image(logo, 8, 7, 0, 16, false, "", 0, link)
+	pdf.CellFormat(22, 0, " ", "", 0, "", false, 0, "")
+	pdf.SetFont(fontFamily, "", 20)
+	pdf.CellFormat(p.width(titlePct), 6, title, "0", 0, "", false, 0, "")
+
+	pdf.SetFont(fontFamily, "", 12)
+	for _, line := range info {
+		pdf.CellFormat(0, 6, line, "0", 2, "", false, 0, "")
+	}
+
+	if len(b.Address) > 0 {
+		pdf.SetFont(fontFamily, "", 9)
+		p.setHeaderColor()
+		for _, line := range b.Address {
+			pdf.CellFormat(0, 4, line, "0", 2, "", false, 0, "")
+		}
+		pdf.SetFont(fontFamily, "", 12)
+		pdf.SetTextColor(0, 0, 0)
+	}
+}
+
+// setBrandFooter method writes the branding footer text, if any, at the current position
+func (p *PDF) setBrandFooter() {
+	if p.branding.FooterText == "" {
+		return
+	}
+	p.file.SetFont(fontFamily, "I", 8)
+	p.file.CellFormat(0, 5, p.branding.FooterText, "", 1, "C", false, 0, "")
+}
+
+// setHeaderColor method sets the text colour used for section titles
+func (p *PDF) setHeaderColor() {
+	r, g, b := rgb(p.branding.HeaderColor, defaultBranding.HeaderColor)
+	p.file.SetTextColor(r, g, b)
+}
+
+// setFillColor method sets the fill colour used for shaded heading rows
+func (p *PDF) setFillColor() {
+	r, g, b := rgb(p.branding.FillColor, defaultBranding.FillColor)
+	p.file.SetFillColor(r, g, b)
+}
+
+// logoFile method returns the logo path, relative logo names are found in the image directory
+// opaqueImage method registers a copy of the image file flattened onto white, an alpha channel would be
+// drawn with a soft mask and transparency group, and returns the name the copy is registered under
+func (p *PDF) opaqueImage(file string) string {
+	pdf := p.file
+	name := file + "#opaque"
+	if pdf.GetImageInfo(name) != nil {
+		return name
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		pdf.SetError(err)
+		return name
+	}
+	defer f.Close()
+	src, _, err := image.Decode(f)
+	if err != nil {
+		pdf.SetError(err)
+		return name
+	}
+
+	// an opaque RGBA image is encoded as a PNG without an alpha channel
+	dst := image.NewRGBA(src.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, draw.Over)
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, dst); err != nil {
+		pdf.SetError(err)
+		return name
+	}
+	pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: "png"}, &buf)
+
+	return name
+}
+
+func (p *PDF) logoFile() string {
+	if filepath.IsAbs(p.branding.Logo) {
+		return p.branding.Logo
+	}
+	return p.imageFile(p.branding.Logo)
+}
+
+// rgb function returns the colour components, falling back to def when c isn't a valid colour
+func rgb(c []int, def []int) (r, g, b int) {
+	if len(c) != 3 {
+		c = def
+	}
+	return c[0], c[1], c[2]
+}
@@ -0,0 +1,167 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Chart constants
+const (
+	chartGapW      = float64(10)
+	chartH         = float64(60)
+	chartLabelH    = float64(3)
+	chartPieSteps  = 72 // polygon segments in a full circle
+	chartTitleH    = float64(6)
+	chartLegendBox = float64(3)
+)
+
+// chartPalette is cycled through for pie slices
+var chartPalette = [][]int{
+	{31, 119, 180},
+	{255, 127, 14},
+	{44, 160, 44},
+	{214, 39, 40},
+	{148, 103, 189},
+	{140, 86, 75},
+	{227, 119, 194},
+}
+
+// chartItem struct is a single labelled value in a chart
+type chartItem struct {
+	label string
+	value float64
+}
+
+// ===================== Chart Methods ========================================================= //
+
+// barChart method draws a vertical bar chart within the box at x, y
+// negative values are drawn as empty bars, the value label still shows the amount
+func (p *PDF) barChart(x, y, w, h float64, title string, items []chartItem) {
+	pdf := p.file
+
+	p.chartTitle(x, y, w, title)
+	if len(items) == 0 {
+		return
+	}
+
+	max := chartMax(items)
+	slotW := w / float64(len(items))
+	barW := slotW * 0.6
+	labelLines := 2
+	plotTop := y + chartTitleH + chartLabelH
+	plotH := h - chartTitleH - chartLabelH - float64(labelLines)*chartLabelH - 1
+	baseY := plotTop + plotH
+
+	r, g, b := rgb(p.branding.HeaderColor, defaultBranding.HeaderColor)
+	pdf.SetFillColor(r, g, b)
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.2)
+	pdf.SetFont(fontFamily, "", 6)
+	pdf.SetTextColor(0, 0, 0)
+
+	for i, item := range items {
+		slotX := x + float64(i)*slotW
+		barX := slotX + (slotW-barW)/2
+		barH := 0.0
+		if max > 0 && item.value > 0 {
+			barH = plotH * item.value / max
+		}
+		if barH > 0 {
+			pdf.Rect(barX, baseY-barH, barW, barH, "F")
+		}
+
+		pdf.SetXY(slotX, baseY-barH-chartLabelH)
+		pdf.CellFormat(slotW, chartLabelH, p.locale.formatCurrency(item.value), "", 0, "C", false, 0, "")
+
+		for j, line := range pdf.SplitText(item.label, slotW) {
+			if j == labelLines {
+				break
+			}
+			pdf.SetXY(slotX, baseY+1+float64(j)*chartLabelH)
+			pdf.CellFormat(slotW, chartLabelH, line, "", 0, "C", false, 0, "")
+		}
+	}
+	pdf.Line(x, baseY, x+w, baseY)
+
+	p.setFillColor()
+}
+
+// pieChart method draws a pie chart with a legend of labels and percentages within the box at x, y
+// only positive values are charted
+func (p *PDF) pieChart(x, y, w, h float64, title string, items []chartItem) {
+	pdf := p.file
+
+	p.chartTitle(x, y, w, title)
+
+	var total float64
+	var slices []chartItem
+	for _, item := range items {
+		if item.value > 0 {
+			slices = append(slices, item)
+			total += item.value
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	plotH := h - chartTitleH
+	radius := math.Min(plotH, w/2) / 2
+	cx, cy := x+radius+1, y+chartTitleH+plotH/2
+	legendX := x + 2*radius + 5
+	legendY := cy - float64(len(slices))*(chartLabelH+1)/2
+
+	pdf.SetFont(fontFamily, "", 7)
+	pdf.SetTextColor(0, 0, 0)
+
+	start := -90.0
+	for i, item := range slices {
+		sweep := 360 * item.value / total
+		c := chartPalette[i%len(chartPalette)]
+		pdf.SetFillColor(c[0], c[1], c[2])
+		pdf.Polygon(sector(cx, cy, radius, start, start+sweep), "F")
+		start += sweep
+
+		ly := legendY + float64(i)*(chartLabelH+1)
+		pdf.Rect(legendX, ly, chartLegendBox, chartLegendBox, "F")
+		pdf.SetXY(legendX+chartLegendBox+1, ly)
+		pct := fmt.Sprintf(p.locale.percentFormat, p.number(100*item.value/total, 1))
+		pdf.CellFormat(x+w-legendX-chartLegendBox-1, chartLabelH, fmt.Sprintf("%s  %s", item.label, pct), "", 0, "", false, 0, "")
+	}
+
+	p.setFillColor()
+}
+
+func (p *PDF) chartTitle(x, y, w float64, title string) {
+	pdf := p.file
+	pdf.SetXY(x, y)
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(w, chartTitleH, title, "", 0, "C", false, 0, "")
+}
+
+// ===================== Helper Functions ====================================================== //
+
+func chartMax(items []chartItem) (max float64) {
+	for _, item := range items {
+		max = math.Max(max, item.value)
+	}
+	return max
+}
+
+// sector function returns the polygon for a pie slice from degStart to degEnd, clockwise from 3 o'clock
+func sector(cx, cy, r, degStart, degEnd float64) []gofpdf.PointType {
+	steps := int(math.Ceil(float64(chartPieSteps) * (degEnd - degStart) / 360))
+	if steps < 1 {
+		steps = 1
+	}
+
+	points := []gofpdf.PointType{{X: cx, Y: cy}}
+	for i := 0; i <= steps; i++ {
+		rad := (degStart + (degEnd-degStart)*float64(i)/float64(steps)) * math.Pi / 180
+		points = append(points, gofpdf.PointType{X: cx + r*math.Cos(rad), Y: cy + r*math.Sin(rad)})
+	}
+	return points
+}
@@ -13,6 +13,7 @@ type Day struct {
 	file   *gofpdf.Fpdf
 	pdf    *PDF
 	record *model.DayRecord
+	verify *verification
 }
 
 func (d *Day) create() (file *gofpdf.Fpdf, err error) {
@@ -21,17 +22,31 @@ func (d *Day) create() (file *gofpdf.Fpdf, err error) {
 	fileNm := fmt.Sprintf("DayReport_%s_%s.pdf", stNm, d.record.Date)
 	d.pdf.setOutputFileName(fileNm)
 
-	d.file = gofpdf.New("P", "mm", "Letter", "")
-	titleStr := "Day Report PDF"
-	d.file.SetTitle(titleStr, false)
-	d.file.SetAuthor("Gales Sales Application", false)
+	lay, err := loadLayout("day", d.record)
+	if err != nil {
+		return nil, err
+	}
+
+	d.verify, err = newVerification(d.record, d.record.StationID.Hex(), d.record.Date, "", d.pdf.generated)
+	if err != nil {
+		return nil, err
+	}
+
+	d.file = d.pdf.newFile(lay)
+	d.pdf.setDocumentInfo(&docInfo{
+		date:        d.record.Date,
+		stationName: d.record.StationName,
+		title:       d.pdf.t(lay.Title),
+	})
+
+	d.pdf.setPageFrame(d.pdf.t("Day Report"), fmt.Sprintf("%s, %s", d.record.StationName, d.record.Date), nil)
 
 	d.file.AddPage()
-	d.setHeader()
-	d.setFuelSummary()
-	d.setNonFuelSummary()
-	d.setTotal()
-	d.setCashCards()
+	err = d.pdf.render(lay, d.record, map[string]func(){
+		"charts":         d.setCharts,
+		"header":         d.setHeader,
+		"reconciliation": d.setReconciliation,
+	})
 
 	return d.file, err
 }
@@ -39,154 +54,105 @@ func (d *Day) create() (file *gofpdf.Fpdf, err error) {
 func (d *Day) setHeader() {
 
 	dte, _ := time.Parse(timeFormatShort, d.record.Date)
-	dteStr := dte.Format(timeFormatLong)
-
-	pdf := d.file
-	pdf.SetFont("Arial", "", 12)
-	pdf.SetFillColor(220, 220, 220)
-	pdf.Image(d.pdf.imageFile("logo.png"), 8, 7, 0, 16, false, "", 0, "http://www.gales.ca")
-	pdf.CellFormat(22, 0, " ", "", 0, "", false, 0, "")
-	pdf.SetFont("Arial", "", 20)
-	pdf.CellFormat(90, 6, "Day Summary Report", "0", 0, "", false, 0, "")
-
-	pdf.SetFont("Arial", "", 12)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Station: %s", d.record.StationName), "0", 2, "", false, 0, "")
-	pdf.CellFormat(0, 6, fmt.Sprintf("Date: %s", dteStr), "0", 2, "", false, 0, "")
+	dteStr := d.pdf.date(dte)
+
+	d.pdf.setQRCode(d.verify)
+	d.pdf.setLetterhead(
+		d.pdf.t("Day Summary Report"),
+		fmt.Sprintf(d.pdf.t("Station: %s"), d.record.StationName),
+		fmt.Sprintf(d.pdf.t("Date: %s"), dteStr),
+	)
 }
 
-func (d *Day) setFuelSummary() {
-	pdf := d.file
+// setCharts method draws fuel dollars by grade beside the split of payment methods
+func (d *Day) setCharts() {
 
-	pdf.SetFillColor(220, 220, 220)
+	pdf := d.file
+	rec := d.record
 
 	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, 8, "Fuel Summary", "B", 1, "", false, 0, "")
-
+	pdf.SetFont(fontFamily, "", 14)
+	d.pdf.setHeaderColor()
+	pdf.CellFormat(0, 8, d.pdf.t("Sales Overview"), "B", 1, "", false, 0, "")
 	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 12)
-	pdf.SetTextColor(0, 0, 0)
-
-	pdf.CellFormat(fuelSaleCol, cellH, "Grade", "", 0, "", true, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Dollar", "", 0, "R", true, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Litre", "", 1, "R", true, 0, "")
 
-	if d.record.Fuel1Dollar != 0 {
-		pdf.CellFormat(fuelSaleCol, cellH, "Regular", "B", 0, "", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel1Dollar, 2), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel1Litre, 3), "B", 1, "R", false, 0, "")
+	var grades []chartItem
+	for _, g := range []chartItem{
+		{label: "Regular", value: rec.Fuel1Dollar},
+		{label: "Mid Grade", value: rec.Fuel2Dollar},
+		{label: "Hi Grade", value: rec.Fuel3Dollar},
+		{label: "Diesel", value: rec.Fuel4Dollar},
+		{label: "Coloured Diesel", value: rec.Fuel5Dollar},
+		{label: "Grade 6", value: rec.Fuel6Dollar},
+	} {
+		if g.value != 0 {
+			grades = append(grades, chartItem{label: d.pdf.t(g.label), value: g.value})
+		}
 	}
 
-	if d.record.Fuel2Dollar != 0 {
-		pdf.CellFormat(fuelSaleCol, cellH, "Mid Grade", "B", 0, "", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel2Dollar, 2), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel2Litre, 3), "B", 1, "R", false, 0, "")
+	var payments []chartItem
+	for _, m := range []chartItem{
+		{label: "Visa", value: rec.Visa},
+		{label: "Mastercard", value: rec.Mastercard},
+		{label: "Amex", value: rec.Amex},
+		{label: "Discover", value: rec.Discover},
+		{label: "Gales", value: rec.Gales},
+		{label: "Debit", value: rec.Debit},
+		{label: "Cash", value: rec.Cash},
+	} {
+		payments = append(payments, chartItem{label: d.pdf.t(m.label), value: m.value})
 	}
 
-	if d.record.Fuel3Dollar != 0 {
-		pdf.CellFormat(fuelSaleCol, cellH, "Hi Grade", "B", 0, "", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel3Dollar, 2), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel3Litre, 3), "B", 1, "R", false, 0, "")
-	}
+	x, y := pdf.GetXY()
+	w := (d.pdf.tableWidth() - chartGapW) / 2
+	d.pdf.barChart(x, y, w, chartH, d.pdf.t("Fuel Sales by Grade"), grades)
+	d.pdf.pieChart(x+w+chartGapW, y, w, chartH, d.pdf.t("Payment Methods"), payments)
 
-	if d.record.Fuel4Dollar != 0 {
-		pdf.CellFormat(fuelSaleCol, cellH, "Diesel", "B", 0, "", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel4Dollar, 2), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel4Litre, 3), "B", 1, "R", false, 0, "")
-	}
-
-	if d.record.Fuel5Dollar != 0 {
-		pdf.CellFormat(fuelSaleCol, cellH, "Coloured Diesel", "B", 0, "", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel5Dollar, 2), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Fuel5Litre, 3), "B", 1, "R", false, 0, "")
-	}
-
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(fuelSaleCol, summaryCellH, "Total Fuel", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, summaryCellH, setFloat(d.record.TotalDollar, 2), "B", 0, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, summaryCellH, setFloat(d.record.TotalLitre, 3), "B", 1, "R", false, 0, "")
-}
-
-func (d *Day) setNonFuelSummary() {
-
-	pdf := d.file
-
-	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, 8, "Non Fuel Summary", "B", 1, "", false, 0, "")
-
-	pdf.SetFont("Arial", "B", 12)
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(fuelSaleCol, cellH, "Total", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.NonFuel, 2), "B", 1, "R", false, 0, "")
-
+	pdf.SetXY(x, y+chartH)
 }
 
-func (d *Day) setTotal() {
+func (d *Day) setReconciliation() {
 
 	pdf := d.file
 
 	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, 8, "Total Sales", "B", 1, "", false, 0, "")
+	pdf.SetFont(fontFamily, "", 14)
+	d.pdf.setHeaderColor()
+	pdf.CellFormat(0, 8, d.pdf.t("Shift Reconciliation"), "B", 1, "", false, 0, "")
 
-	pdf.SetFont("Arial", "B", 12)
+	pdf.SetFont(fontFamily, "", 12)
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(fuelSaleCol, cellH, "Total", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Total, 2), "B", 1, "R", false, 0, "")
-
-}
-
-func (d *Day) setCashCards() {
-
-	pdf := d.file
-
-	pdf.SetFillColor(220, 220, 220)
-	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, 8, "Cash & Cards", "B", 1, "", false, 0, "")
+	if d.record.ReconcileError != "" {
+		pdf.SetTextColor(200, 0, 0)
+		pdf.CellFormat(0, cellH, d.pdf.t("Shifts unavailable for reconciliation"), "", 1, "", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		return
+	}
+	if len(d.record.Discrepancies) == 0 {
+		pdf.CellFormat(0, cellH, fmt.Sprintf(d.pdf.t("Day totals agree with %d shift reports"), d.record.ShiftCount), "", 1, "", false, 0, "")
+		return
+	}
 
-	pdf.SetFont("Arial", "", 12)
+	fieldW, valueW := d.pdf.width(fieldPct), d.pdf.width(valuePct)
+	d.pdf.setFillColor()
+	pdf.CellFormat(fieldW, cellH, d.pdf.t("Field"), "", 0, "", true, 0, "")
+	pdf.CellFormat(valueW, cellH, d.pdf.t("Day"), "", 0, "R", true, 0, "")
+	pdf.CellFormat(valueW, cellH, d.pdf.t("Shifts"), "", 0, "R", true, 0, "")
+	pdf.CellFormat(valueW, cellH, d.pdf.t("Difference"), "", 1, "R", true, 0, "")
+
+	pdf.SetTextColor(200, 0, 0)
+	for _, ds := range d.record.Discrepancies {
+		pdf.CellFormat(fieldW, cellH, d.pdf.t(ds.Field), "B", 0, "", false, 0, "")
+		if ds.Unit == model.UnitLitre {
+			d.pdf.numberCell(valueW, cellH, ds.DayValue, litreDecimals, "B", 0)
+			d.pdf.numberCell(valueW, cellH, ds.ShiftValue, litreDecimals, "B", 0)
+			d.pdf.numberCell(valueW, cellH, ds.Difference, litreDecimals, "B", 1)
+			continue
+		}
+		d.pdf.currencyCell(valueW, cellH, ds.DayValue, "B", 0)
+		d.pdf.currencyCell(valueW, cellH, ds.ShiftValue, "B", 0)
+		d.pdf.currencyCell(valueW, cellH, ds.Difference, "B", 1)
+	}
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(fuelSaleCol, cellH, "Visa", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Visa, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Mastercard", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Mastercard, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Gales", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Gales, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Amex", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Amex, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Discover", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Discover, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Debit", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Debit, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Diesel Discount", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.DieselDiscount, 2), "B", 1, "R", false, 0, "")
-
-	pdf.CellFormat(fuelSaleCol, cellH, "Lottery Payout", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.LotteryPayout, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Supplier Payout", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Payout, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Cash", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Cash, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Gales Loyalty Redeemed", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.GalesLoyaltyRedeem, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Gift Cert Redeemable", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.GiftCertRedeem, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "OS Adjusted", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.OSAdjusted, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Drive Offs / NSF", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.DriveOffNSF, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Write Offs", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.WriteOff, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, "Other", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.Other, 2), "B", 1, "R", false, 0, "")
-
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(fuelSaleCol, cellH, "Total", "B", 0, "", false, 0, "")
-	pdf.CellFormat(fuelSaleCol, cellH, setFloat(d.record.TotalCashCards, 2), "B", 1, "R", false, 0, "")
 }
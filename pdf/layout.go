@@ -0,0 +1,398 @@
+package pdf
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"gopkg.in/yaml.v2"
+)
+
+// Report layouts are YAML templates embedded from the layout directory, one per report type.
+//
+// A layout lists its sections in order. A section is either a custom section, rendered by
+// the report in Go (ie: header, journal), or a titled table of rows. Each row has a label
+// and one or more values, where a value names a record field (promoted fields of embedded
+// structs resolve directly, nested structs use a dotted path) and how to format it:
+//
+//	title: Shift Report PDF       # document title, translated
+//	orientation: P                # optional, P or L, the request may override it
+//	pageSize: Letter              # optional, Letter or A4, the request may override it
+//	widths: [45, 20]              # default column widths as a percentage of the printable width
+//	sections:
+//	  - custom: header
+//	  - title: Sales              # section title, translated
+//	    columns: [Grade, Dollar]  # optional shaded heading row
+//	    widths: [25, 25]          # optional, overrides the layout widths
+//	    pageBreak: true           # optional, start the section on a new page
+//	    rows:
+//	      - label: Other Fuel     # translated, omit for a row of values only
+//	        style: summary        # bold, or summary (bold and taller)
+//	        showIf: OtherFuelDollar
+//	        when: positive        # nonZero (default) or positive
+//	        spaceAfter: 3         # gap in mm after the row
+//	        noBorder: true        # omit the bottom border
+//	        values:
+//	          - field: OtherFuelDollar
+//	            format: currency  # currency (default), number, text or yesNo
+//	            decimals: 3       # for number, defaults to 2
+//	            align: L          # for text and yesNo, defaults to R
+//	            fill: true        # span the remaining page width
+
+//go:embed layout/*.yml
+var layoutFiles embed.FS
+
+const layoutDir = "layout"
+
+// Value formats
+const (
+	formatCurrency = "currency"
+	formatNumber   = "number"
+	formatText     = "text"
+	formatYesNo    = "yesNo"
+)
+
+// Row styles
+const (
+	styleBold    = "bold"
+	styleSummary = "summary"
+)
+
+// Row conditions
+const (
+	whenNonZero  = "nonZero"
+	whenPositive = "positive"
+)
+
+// layout struct
+type layout struct {
+	Orientation string     `yaml:"orientation"`
+	PageSize    string     `yaml:"pageSize"`
+	Sections    []*section `yaml:"sections"`
+	Title       string     `yaml:"title"`
+	Widths      []float64  `yaml:"widths"`
+}
+
+// section struct
+type section struct {
+	Columns   []string  `yaml:"columns"`
+	Custom    string    `yaml:"custom"`
+	PageBreak bool      `yaml:"pageBreak"`
+	Rows      []*row    `yaml:"rows"`
+	Title     string    `yaml:"title"`
+	Widths    []float64 `yaml:"widths"`
+}
+
+// row struct
+type row struct {
+	Label      string   `yaml:"label"`
+	NoBorder   bool     `yaml:"noBorder"`
+	ShowIf     string   `yaml:"showIf"`
+	SpaceAfter float64  `yaml:"spaceAfter"`
+	Style      string   `yaml:"style"`
+	Values     []*value `yaml:"values"`
+	When       string   `yaml:"when"`
+}
+
+// value struct
+type value struct {
+	Align    string `yaml:"align"`
+	Decimals *int   `yaml:"decimals"`
+	Field    string `yaml:"field"`
+	Fill     bool   `yaml:"fill"`
+	Format   string `yaml:"format"`
+}
+
+// loadLayout function reads the named layout and checks it against the record it will render
+func loadLayout(name string, record interface{}) (l *layout, err error) {
+
+	file, err := layoutFiles.ReadFile(path.Join(layoutDir, name+".yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	l = &layout{}
+	if err = yaml.UnmarshalStrict(file, l); err != nil {
+		return nil, fmt.Errorf("Invalid layout %s: %s", name, err.Error())
+	}
+	if err = l.validate(record); err != nil {
+		return nil, fmt.Errorf("Invalid layout %s: %s", name, err.Error())
+	}
+
+	return l, nil
+}
+
+// validate method checks every field path, format and style, and that each row fits its widths
+func (l *layout) validate(record interface{}) error {
+
+	switch l.Orientation {
+	case "", model.OrientationPortrait, model.OrientationLandscape:
+	default:
+		return fmt.Errorf("unknown orientation %q", l.Orientation)
+	}
+	switch l.PageSize {
+	case "", model.PageSizeLetter, model.PageSizeA4:
+	default:
+		return fmt.Errorf("unknown page size %q", l.PageSize)
+	}
+
+	for _, s := range l.Sections {
+		if s.Custom != "" {
+			continue
+		}
+		widths := l.sectionWidths(s)
+		if len(s.Columns) > len(widths) {
+			return fmt.Errorf("section %q has more columns than widths", s.Title)
+		}
+		var total float64
+		for _, w := range widths {
+			total += w
+		}
+		if total > 100 {
+			return fmt.Errorf("section %q widths exceed 100%% of the page width", s.Title)
+		}
+		for _, r := range s.Rows {
+			switch r.Style {
+			case "", styleBold, styleSummary:
+			default:
+				return fmt.Errorf("unknown style %q in section %q", r.Style, s.Title)
+			}
+			switch r.When {
+			case "", whenNonZero, whenPositive:
+			default:
+				return fmt.Errorf("unknown condition %q in section %q", r.When, s.Title)
+			}
+			if r.ShowIf != "" {
+				if _, err := fieldFloat(record, r.ShowIf); err != nil {
+					return err
+				}
+			}
+
+			if len(r.Values) == 0 {
+				return fmt.Errorf("row %q in section %q has no values", r.Label, s.Title)
+			}
+			col := 0
+			if r.Label != "" {
+				col++
+			}
+			for _, v := range r.Values {
+				if !v.Fill && col >= len(widths) {
+					return fmt.Errorf("row %q has more columns than section %q has widths", r.Label, s.Title)
+				}
+				col++
+			}
+
+			for _, v := range r.Values {
+				fv, err := fieldValue(record, v.Field)
+				if err != nil {
+					return err
+				}
+				kind := fv.Kind()
+				switch v.format() {
+				case formatCurrency, formatNumber:
+					if kind != reflect.Float64 {
+						return fmt.Errorf("field %s is not a number", v.Field)
+					}
+				case formatYesNo:
+					if kind != reflect.Bool {
+						return fmt.Errorf("field %s is not a bool", v.Field)
+					}
+				case formatText:
+				default:
+					return fmt.Errorf("unknown format %q for field %s", v.Format, v.Field)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *layout) sectionWidths(s *section) []float64 {
+	if len(s.Widths) > 0 {
+		return s.Widths
+	}
+	return l.Widths
+}
+
+func (v *value) format() string {
+	if v.Format == "" {
+		return formatCurrency
+	}
+	return v.Format
+}
+
+func (v *value) decimals() int {
+	if v.Decimals == nil {
+		return 2
+	}
+	return *v.Decimals
+}
+
+func (v *value) align() string {
+	if v.Align == "" {
+		return "R"
+	}
+	return v.Align
+}
+
+// ===================== Render Methods ======================================================== //
+
+// render method draws each layout section in order, custom sections are looked up by name
+func (p *PDF) render(l *layout, record interface{}, custom map[string]func()) error {
+
+	for _, s := range l.Sections {
+		if s.PageBreak {
+			p.file.AddPage()
+		}
+		if s.Custom != "" {
+			fn, ok := custom[s.Custom]
+			if !ok {
+				return fmt.Errorf("Invalid layout: unknown custom section %q", s.Custom)
+			}
+			fn()
+			continue
+		}
+		p.renderSection(s, p.widths(l.sectionWidths(s)), record)
+	}
+
+	return p.file.Error()
+}
+
+func (p *PDF) renderSection(s *section, widths []float64, record interface{}) {
+
+	pdf := p.file
+	pdf.Ln(headerSpacing)
+	pdf.SetFont(fontFamily, "", 14)
+	p.setHeaderColor()
+	pdf.CellFormat(0, 8, p.t(s.Title), "B", 1, "", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont(fontFamily, "", 12)
+	pdf.SetTextColor(0, 0, 0)
+	if len(s.Columns) > 0 {
+		p.setFillColor()
+		for i, c := range s.Columns {
+			align, ln := "R", 0
+			if i == 0 {
+				align = ""
+			}
+			if i == len(s.Columns)-1 {
+				ln = 1
+			}
+			pdf.CellFormat(widths[i], cellH, p.t(c), "", ln, align, true, 0, "")
+		}
+	}
+
+	for _, r := range s.Rows {
+		p.renderRow(r, widths, record)
+	}
+}
+
+func (p *PDF) renderRow(r *row, widths []float64, record interface{}) {
+
+	if !r.visible(record) {
+		return
+	}
+
+	pdf := p.file
+	h, style := cellH, ""
+	switch r.Style {
+	case styleBold:
+		style = "B"
+	case styleSummary:
+		h, style = summaryCellH, "B"
+	}
+	border := "B"
+	if r.NoBorder {
+		border = ""
+	}
+	pdf.SetFont(fontFamily, style, 12)
+
+	col := 0
+	if r.Label != "" {
+		pdf.CellFormat(widths[col], h, p.t(r.Label), border, 0, "", false, 0, "")
+		col++
+	}
+	for i, v := range r.Values {
+		w, ln := float64(0), 0
+		if !v.Fill {
+			w = widths[col]
+		}
+		if i == len(r.Values)-1 {
+			ln = 1
+		}
+		col++
+
+		fv, _ := fieldValue(record, v.Field)
+		switch v.format() {
+		case formatCurrency:
+			p.currencyCell(w, h, fv.Float(), border, ln)
+		case formatNumber:
+			p.numberCell(w, h, fv.Float(), v.decimals(), border, ln)
+		case formatYesNo:
+			pdf.CellFormat(w, h, p.yesNo(fv.Bool()), border, ln, v.align(), false, 0, "")
+		default:
+			pdf.CellFormat(w, h, fmt.Sprintf("%v", fv.Interface()), border, ln, v.align(), false, 0, "")
+		}
+	}
+
+	if r.SpaceAfter > 0 {
+		pdf.Ln(r.SpaceAfter)
+	}
+}
+
+// widths method converts percentage widths to mm for the current page
+func (p *PDF) widths(pcts []float64) []float64 {
+	ws := make([]float64, len(pcts))
+	for i, pct := range pcts {
+		ws[i] = p.width(pct)
+	}
+	return ws
+}
+
+// visible method applies the row showIf condition, rows without one are always shown
+func (r *row) visible(record interface{}) bool {
+	if r.ShowIf == "" {
+		return true
+	}
+	val, _ := fieldFloat(record, r.ShowIf)
+	if r.When == whenPositive {
+		return val > 0
+	}
+	return val != 0
+}
+
+// ===================== Helper Functions ====================================================== //
+
+// fieldValue function resolves a field path, ie: "Fuel1Dollar" or "CardFields.Visa", on record
+func fieldValue(record interface{}, fieldPath string) (v reflect.Value, err error) {
+
+	v = reflect.ValueOf(record)
+	for _, name := range strings.Split(fieldPath, ".") {
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Struct {
+			return v, fmt.Errorf("unknown field %s", fieldPath)
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return v, fmt.Errorf("unknown field %s", fieldPath)
+		}
+	}
+
+	return v, nil
+}
+
+func fieldFloat(record interface{}, fieldPath string) (float64, error) {
+	v, err := fieldValue(record, fieldPath)
+	if err != nil {
+		return 0, err
+	}
+	if v.Kind() != reflect.Float64 {
+		return 0, fmt.Errorf("field %s is not a number", fieldPath)
+	}
+	return v.Float(), nil
+}
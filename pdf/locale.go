@@ -0,0 +1,226 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// locale struct holds the translation catalogue and the date and number conventions for a language
+type locale struct {
+	currencyFormat string // fmt verb placing the currency symbol around the formatted number
+	dateLayout     string
+	days           []string // indexed by time.Weekday, nil keeps the English names
+	months         []string // indexed by time.Month - 1, nil keeps the English names
+	decimalSep     string
+	groupSep       string
+	messages       map[string]string
+	negativeParens bool // accounting style, ie: ($12.50) rather than -$12.50
+	negativeRed    bool
+	percentFormat  string
+}
+
+var locales = map[string]*locale{
+	model.LocaleEnCA: {
+		currencyFormat: "$%s",
+		dateLayout:     timeFormatLong,
+		decimalSep:     ".",
+		groupSep:       ",",
+		negativeParens: true,
+		negativeRed:    true,
+		percentFormat:  "%s%%",
+	},
+	model.LocaleFrCA: {
+		currencyFormat: "%s\u00a0$",
+		dateLayout:     "Mon 2 Jan 2006",
+		days:           []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		months:         []string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		decimalSep:     ",",
+		groupSep:       "\u00a0",
+		messages:       messagesFr,
+		negativeParens: true,
+		negativeRed:    true,
+		percentFormat:  "%s\u00a0%%",
+	},
+}
+
+// messagesFr is the French catalogue, keyed by the English label
+var messagesFr = map[string]string{
+	// titles and headers
+	"Day Report PDF":     "Rapport du jour PDF",
+	"Day Summary Report": "Rapport sommaire du jour",
+	"Shift Report PDF":   "Rapport de quart PDF",
+	"Shift Report":       "Rapport de quart",
+	"Station: %s":        "Station : %s",
+	"Date: %s":           "Date : %s",
+	"Record: %s":         "Dossier : %s",
+	"Page %d of {nb}":    "Page %d de {nb}",
+	"Day Report":         "Rapport du jour",
+	"Generated %s":       "Généré le %s",
+	"Generated %s by %s": "Généré le %s par %s",
+	"Version %s":         "Version %s",
+
+	// section titles
+	"Attendant":            "Préposé",
+	"Cash & Cards":         "Espèces et cartes",
+	"Fuel Summary":         "Sommaire du carburant",
+	"Journal Entries":      "Écritures de journal",
+	"Non Fuel Summary":     "Sommaire hors carburant",
+	"Overshort":            "Écart de caisse",
+	"Sales":                "Ventes",
+	"Sales Overview":       "Aperçu des ventes",
+	"Shift Reconciliation": "Rapprochement des quarts",
+	"Total Sales":          "Ventes totales",
+
+	// fuel
+	"Coloured Diesel":      "Diesel coloré",
+	"Diesel":               "Diesel",
+	"Dollar":               "Dollars",
+	"Fuel":                 "Carburant",
+	"Fuel Adjustment":      "Ajustement carburant",
+	"Grade":                "Catégorie",
+	"Grade 6":              "Catégorie 6",
+	"Hi Grade":             "Super",
+	"Litre":                "Litres",
+	"Mid Grade":            "Intermédiaire",
+	"Non-Fuel":             "Hors carburant",
+	"Other Fuel":           "Autre carburant",
+	"Regular":              "Ordinaire",
+	"Total Fuel":           "Total carburant",
+	"Total Fuel (L)":       "Total carburant (L)",
+	"Total Other Fuel (L)": "Total autre carburant (L)",
+
+	// cash and cards
+	"Amex":                      "Amex",
+	"Cash":                      "Espèces",
+	"Cash Subtotal":             "Sous-total espèces",
+	"Debit":                     "Débit",
+	"Diesel Discount":           "Rabais diesel",
+	"Discover":                  "Discover",
+	"Drive Offs / NSF":          "Départs sans payer / NSF",
+	"Gales":                     "Gales",
+	"Gales Loyalty Redeemed":    "Fidélité Gales échangée",
+	"Gift Cert Redeemable":      "Certificats-cadeaux échangeables",
+	"Gift Certificate Redeemed": "Certificats-cadeaux échangés",
+	"Lottery Payout":            "Paiements loterie",
+	"Mastercard":                "Mastercard",
+	"OS Adjust":                 "Ajustement d'écart",
+	"OS Adjusted":               "Écart ajusté",
+	"Other":                     "Autre",
+	"Subtotal":                  "Sous-total",
+	"Supplier Payout":           "Paiements fournisseurs",
+	"Total":                     "Total",
+	"Total Cards":               "Total cartes",
+	"Total Cash":                "Total espèces",
+	"Total Cash & Cards":        "Total espèces et cartes",
+	"Visa":                      "Visa",
+	"Write Offs":                "Radiations",
+
+	// charts
+	"Fuel Sales by Grade": "Ventes de carburant par catégorie",
+	"Payment Methods":     "Modes de paiement",
+
+	// reconciliation
+	"Day":                                    "Jour",
+	"Day totals agree with %d shift reports": "Les totaux du jour concordent avec %d rapports de quart",
+	"Difference":                             "Différence",
+	"Field":                                  "Champ",
+	"Shifts":                                 "Quarts",
+	"Shifts unavailable for reconciliation":  "Quarts non disponibles pour le rapprochement",
+
+	// attendant, overshort and journal
+	"Amount":            "Montant",
+	"Comments":          "Commentaires",
+	"Name":              "Nom",
+	"No":                "Non",
+	"Overshort Checked": "Écart vérifié",
+	"Overshort amount":  "Montant de l'écart",
+	"Product":           "Produit",
+	"Sheet Completed":   "Feuille complétée",
+	"Yes":               "Oui",
+	draftText:           "BROUILLON – FEUILLE INCOMPLÈTE",
+	"Sheet Completed: %s    Overshort Checked: %s": "Feuille complétée : %s    Écart vérifié : %s",
+}
+
+// getLocale function returns the requested locale, falling back to model.DefaultLocale
+func getLocale(loc string) *locale {
+	if l, ok := locales[loc]; ok {
+		return l
+	}
+	return locales[model.DefaultLocale]
+}
+
+// translate method returns the catalogue entry for key, or the key itself when there is none
+func (l *locale) translate(key string) string {
+	if msg, ok := l.messages[key]; ok {
+		return msg
+	}
+	return key
+}
+
+// formatDate method formats t with the locale date layout and day and month names
+func (l *locale) formatDate(t time.Time) string {
+	str := t.Format(l.dateLayout)
+	if l.days != nil {
+		str = strings.Replace(str, t.Format("Mon"), l.days[t.Weekday()], 1)
+	}
+	if l.months != nil {
+		str = strings.Replace(str, t.Format("Jan"), l.months[t.Month()-1], 1)
+	}
+	return str
+}
+
+// formatNumber method formats val to dec decimals using the locale group and decimal separators
+func (l *locale) formatNumber(val float64, dec int) string {
+	if math.IsNaN(val) {
+		return ""
+	}
+
+	str := setFloat(math.Abs(val), dec)
+	intPart, fracPart := str, ""
+	if i := strings.Index(str, "."); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+
+	var b strings.Builder
+	if isNegative(val, dec) {
+		b.WriteString("-")
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.groupSep)
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteString(l.decimalSep)
+		b.WriteString(fracPart)
+	}
+
+	return b.String()
+}
+
+// formatCurrency method formats val as a locale currency amount, ie: $1,234.56 or 1 234,56 $
+// negatives are wrapped in parentheses when the locale uses accounting style
+func (l *locale) formatCurrency(val float64) string {
+	if math.IsNaN(val) {
+		return ""
+	}
+
+	str := fmt.Sprintf(l.currencyFormat, l.formatNumber(math.Abs(val), 2))
+	if !isNegative(val, 2) {
+		return str
+	}
+	if l.negativeParens {
+		return "(" + str + ")"
+	}
+	return "-" + str
+}
+
+// isNegative function reports whether val is still negative once rounded to dec decimals
+func isNegative(val float64, dec int) bool {
+	return val < 0 && strings.Trim(setFloat(val, dec), "-0.") != ""
+}
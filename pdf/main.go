@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jung-kurt/gofpdf"
 	"github.com/pulpfree/gsales-pdf-reports/model"
@@ -17,12 +18,43 @@ import (
 // PDF struct
 type PDF struct {
 	OutputFileName string
+	archive        bool
+	branding       *model.Branding
 	file           *gofpdf.Fpdf
+	generated      time.Time
+	locale         *locale
+	orientation    string
+	pageSize       string
+	protection     *protection
 	reportType     *model.ReportType
+	timeZone       *time.Location
+	user           string
+	verifyURL      string
+	version        string
+}
+
+// Options struct
+type Options struct {
+	Branding  *model.Branding
+	Format    string    // model.FormatArchive for archival output
+	Generated time.Time // report generation time carried in the QR code, defaults to now
+	Locale    string
+	// Orientation and PageSize override the report layout page setup, ie: model.OrientationLandscape and model.PageSizeA4
+	Orientation string
+	PageSize    string
+	// OwnerPassword, Password and Permissions encrypt the document, it is left unencrypted when Password is empty
+	OwnerPassword string
+	Password      string
+	Permissions   []string
+	TimeZone      *time.Location // footer timestamps are shown in this zone, defaults to the generated time zone
+	User          string         // requesting user shown in the footer
+	VerifyURL     string         // web app URL the QR code links to, a compact payload is encoded when empty
+	Version       string         // application version shown in the footer
 }
 
 // Constants
 const (
+	fontDir  = pdfDir + "/font"
 	imageDir = pdfDir + "/image"
 	// pdfDir   = ".." // local testing if no symbolic link from image in report directory
 	pdfDir          = "."
@@ -30,23 +62,85 @@ const (
 	timeFormatShort = "2006-01-02"
 )
 
+// fontFamily is the embedded UTF-8 font family used throughout the reports
+const fontFamily = "DejaVu"
+
+// fontFiles maps the font styles used in the reports to the TTF files in fontDir
+var fontFiles = map[string]string{
+	"":  "DejaVuSansCondensed.ttf",
+	"B": "DejaVuSansCondensed-Bold.ttf",
+	"I": "DejaVuSansCondensed-Oblique.ttf",
+}
+
+// draftText is stamped on shift reports that have not been finalised
+const draftText = "DRAFT – SHEET INCOMPLETE"
+
+// Default page setup, used when neither the request nor the report layout sets one
+const (
+	defaultOrientation = model.OrientationPortrait
+	defaultPageSize    = model.PageSizeLetter
+)
+
 // Spacing constants
 const (
-	fuelSaleCol   = float64(50)
 	cellH         = float64(7)
 	summaryCellH  = float64(9)
 	headerSpacing = float64(5)
-	labelW        = float64(90)
-	valueW        = float64(40)
+)
+
+// Column widths, as a percentage of the printable page width
+const (
+	fieldPct = float64(25)
+	titlePct = float64(45)
+	valuePct = float64(20)
+)
+
+// litreDecimals is the precision of fuel volumes, as in the report layouts
+const litreDecimals = 3
+
+// Journal table constants, widths are a percentage of the printable page width and
+// comments take the remaining width
+const (
+	journalAmountPct  = float64(15)
+	journalGapPct     = float64(2.5)
+	journalLineH      = float64(5)
+	journalPadding    = float64(1)
+	journalProductPct = float64(25)
 )
 
 // Init function
-func Init() *PDF {
-	return new(PDF)
+func Init(opts *Options) *PDF {
+	if opts == nil {
+		opts = &Options{}
+	}
+	generated := opts.Generated
+	if generated.IsZero() {
+		generated = time.Now()
+	}
+	return &PDF{
+		archive:     opts.Format == model.FormatArchive,
+		branding:    model.MergeBranding(opts.Branding, defaultBranding),
+		generated:   generated,
+		locale:      getLocale(opts.Locale),
+		orientation: opts.Orientation,
+		pageSize:    opts.PageSize,
+		protection: &protection{
+			ownerPassword: opts.OwnerPassword,
+			password:      opts.Password,
+			permissions:   opts.Permissions,
+		},
+		timeZone:  opts.TimeZone,
+		user:      opts.User,
+		verifyURL: opts.VerifyURL,
+		version:   opts.Version,
+	}
 }
 
 // OutputFile method
 func (p *PDF) OutputFile() (buf bytes.Buffer, err error) {
+	if err = p.protect(); err != nil {
+		return buf, err
+	}
 	if err := p.file.Output(&buf); err != nil {
 		return buf, err
 	}
@@ -60,6 +154,9 @@ func (p *PDF) OutputToDisk(dir string) (err error) {
 	if err != nil {
 		return err
 	}
+	if err = p.protect(); err != nil {
+		return err
+	}
 	outputPath := path.Join(fp, dir, p.OutputFileName)
 	err = p.file.OutputFileAndClose(outputPath)
 
@@ -89,6 +186,10 @@ func (p *PDF) CreateShiftFile(record *model.ShiftRecord) (err error) {
 
 // ===================== Helper Methods ========================================================= /
 
+func (p *PDF) fontFile(fileStr string) string {
+	return filepath.Join(fontDir, fileStr)
+}
+
 func (p *PDF) imageFile(fileStr string) string {
 	return filepath.Join(imageDir, fileStr)
 }
@@ -97,6 +198,68 @@ func (p *PDF) setOutputFileName(name string) {
 	p.OutputFileName = name
 }
 
+// newFile method creates the gofpdf document and embeds the UTF-8 font family
+// the page setup requested takes precedence over the layout, which overrides the defaults
+func (p *PDF) newFile(l *layout) *gofpdf.Fpdf {
+	orientation := firstOf(p.orientation, l.Orientation, defaultOrientation)
+	size := firstOf(p.pageSize, l.PageSize, defaultPageSize)
+
+	p.file = gofpdf.New(orientation, "mm", size, "")
+	for style, fileStr := range fontFiles {
+		p.file.AddUTF8Font(fontFamily, style, p.fontFile(fileStr))
+	}
+	return p.file
+}
+
+// t method returns the translated label
+func (p *PDF) t(key string) string {
+	return p.locale.translate(key)
+}
+
+// number method returns val formatted for the locale
+func (p *PDF) number(val float64, dec int) string {
+	return p.locale.formatNumber(val, dec)
+}
+
+// currencyCell method writes a right aligned currency value, in red when negative and the locale calls for it
+func (p *PDF) currencyCell(w, h, val float64, borderStr string, ln int) {
+	p.valueCell(w, h, p.locale.formatCurrency(val), isNegative(val, 2), borderStr, ln)
+}
+
+// numberCell method writes a right aligned number, in red when negative and the locale calls for it
+func (p *PDF) numberCell(w, h, val float64, dec int, borderStr string, ln int) {
+	p.valueCell(w, h, p.number(val, dec), isNegative(val, dec), borderStr, ln)
+}
+
+func (p *PDF) valueCell(w, h float64, str string, negative bool, borderStr string, ln int) {
+	if !negative || !p.locale.negativeRed {
+		p.file.CellFormat(w, h, str, borderStr, ln, "R", false, 0, "")
+		return
+	}
+
+	r, g, b := p.file.GetTextColor()
+	p.file.SetTextColor(200, 0, 0)
+	p.file.CellFormat(w, h, str, borderStr, ln, "R", false, 0, "")
+	p.file.SetTextColor(r, g, b)
+}
+
+// tableWidth method returns the printable width between the page margins
+func (p *PDF) tableWidth() float64 {
+	pageW, _ := p.file.GetPageSize()
+	left, _, right, _ := p.file.GetMargins()
+	return pageW - left - right
+}
+
+// width method converts a percentage of the printable page width to mm
+func (p *PDF) width(pct float64) float64 {
+	return p.tableWidth() * pct / 100
+}
+
+// date method returns the long date formatted for the locale
+func (p *PDF) date(t time.Time) string {
+	return p.locale.formatDate(t)
+}
+
 func setFloat(val float64, dec int) string {
 	if true == math.IsNaN(val) {
 		return ""
@@ -105,6 +268,23 @@ func setFloat(val float64, dec int) string {
 	return fmt.Sprintf(floatFmt, val)
 }
 
+func (p *PDF) yesNo(b bool) string {
+	if b {
+		return p.t("Yes")
+	}
+	return p.t("No")
+}
+
+// firstOf function returns the first non-empty string
+func firstOf(strs ...string) string {
+	for _, s := range strs {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
 func setFileOutputName(name string) string {
 	return strings.Replace(name, " ", "-", -1)
 }
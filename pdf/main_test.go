@@ -0,0 +1,338 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"github.com/stretchr/testify/suite"
+)
+
+const date = "2019-12-21"
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	en *locale
+	fr *locale
+}
+
+// SetupTest method
+func (s *UnitSuite) SetupTest() {
+	s.en = getLocale(model.LocaleEnCA)
+	s.fr = getLocale(model.LocaleFrCA)
+}
+
+// TestGetLocale method
+func (s *UnitSuite) TestGetLocale() {
+	s.Equal(s.en, getLocale(""))
+	s.Equal(s.en, getLocale("invalid"))
+}
+
+// TestTranslate method
+func (s *UnitSuite) TestTranslate() {
+	s.Equal("Fuel Summary", s.en.translate("Fuel Summary"))
+	s.Equal("Sommaire du carburant", s.fr.translate("Fuel Summary"))
+	s.Equal("not in catalogue", s.fr.translate("not in catalogue"))
+}
+
+// TestFormatDate method
+func (s *UnitSuite) TestFormatDate() {
+	dte, _ := time.Parse(timeFormatShort, date)
+	s.Equal("Sat Dec 21, 2019", s.en.formatDate(dte))
+	s.Equal("sam. 21 déc. 2019", s.fr.formatDate(dte))
+}
+
+// TestFormatNumber method
+func (s *UnitSuite) TestFormatNumber() {
+	s.Equal("1,234.56", s.en.formatNumber(1234.56, 2))
+	s.Equal("-1,234,567.890", s.en.formatNumber(-1234567.89, 3))
+	s.Equal("123.00", s.en.formatNumber(123, 2))
+	s.Equal("0.00", s.en.formatNumber(-0.001, 2))
+	s.Equal("1\u00a0234,56", s.fr.formatNumber(1234.56, 2))
+}
+
+// TestFormatCurrency method
+func (s *UnitSuite) TestFormatCurrency() {
+	s.Equal("$1,234.56", s.en.formatCurrency(1234.56))
+	s.Equal("($12.50)", s.en.formatCurrency(-12.5))
+	s.Equal("$0.00", s.en.formatCurrency(-0.001))
+	s.Equal("1\u00a0234,56\u00a0$", s.fr.formatCurrency(1234.56))
+	s.Equal("(12,50\u00a0$)", s.fr.formatCurrency(-12.5))
+
+	minus := &locale{currencyFormat: "$%s", decimalSep: ".", groupSep: ","}
+	s.Equal("-$12.50", minus.formatCurrency(-12.5))
+}
+
+// TestLoadLayout method
+func (s *UnitSuite) TestLoadLayout() {
+	l, err := loadLayout("day", &model.DayRecord{})
+	s.NoError(err)
+	s.Equal("Day Report PDF", l.Title)
+
+	l, err = loadLayout("shift", &model.ShiftRecord{})
+	s.NoError(err)
+	s.Equal("Shift Report PDF", l.Title)
+
+	// the shift layout references fields the day record doesn't have
+	_, err = loadLayout("shift", &model.DayRecord{})
+	s.Error(err)
+
+	_, err = loadLayout("noexist", &model.DayRecord{})
+	s.Error(err)
+}
+
+// TestFieldValue method
+func (s *UnitSuite) TestFieldValue() {
+	record := &model.ShiftRecord{AttendantFields: model.AttendantFields{AttendantName: "Gagné, Élise"}}
+	record.Visa = 12.5
+
+	v, err := fieldValue(record, "AttendantName")
+	s.NoError(err)
+	s.Equal("Gagné, Élise", v.String())
+
+	f, err := fieldFloat(record, "CardFields.Visa")
+	s.NoError(err)
+	s.Equal(12.5, f)
+
+	_, err = fieldFloat(record, "AttendantName")
+	s.Error(err)
+
+	_, err = fieldValue(record, "Visa.Amount")
+	s.Error(err)
+}
+
+// TestSector method
+func (s *UnitSuite) TestSector() {
+	points := sector(50, 50, 10, 0, 90)
+	s.Equal(float64(50), points[0].X)
+	s.InDelta(60, points[1].X, 0.0001)
+	s.InDelta(50, points[1].Y, 0.0001)
+	last := points[len(points)-1]
+	s.InDelta(50, last.X, 0.0001)
+	s.InDelta(60, last.Y, 0.0001)
+}
+
+// TestChartMax method
+func (s *UnitSuite) TestChartMax() {
+	s.Equal(float64(0), chartMax(nil))
+	s.Equal(float64(12.5), chartMax([]chartItem{{value: -20}, {value: 12.5}, {value: 3}}))
+}
+
+// TestVerificationPayload method
+func (s *UnitSuite) TestVerificationPayload() {
+	record := &model.ShiftRecord{RecordNumber: "2020-08-11-2", StationName: "Bridge Street"}
+	generated := time.Unix(1597176000, 0)
+	v, err := newVerification(record, "56cf1815982d82b0f3000001", "", record.RecordNumber, generated)
+	s.NoError(err)
+	s.Len(v.hash, qrHashLen)
+
+	payload, err := v.payload("")
+	s.NoError(err)
+	s.Equal("GSR1|56cf1815982d82b0f3000001|2020-08-11-2|1597176000|"+v.hash, payload)
+
+	payload, err = v.payload("https://example.com/verify")
+	s.NoError(err)
+	s.Equal("https://example.com/verify?generated=1597176000&hash="+v.hash+"&record=2020-08-11-2&station=56cf1815982d82b0f3000001", payload)
+
+	record.OvershortAmount = 1
+	changed, err := newVerification(record, "56cf1815982d82b0f3000001", "", record.RecordNumber, generated)
+	s.NoError(err)
+	s.NotEqual(v.hash, changed.hash)
+}
+
+// TestPermissionFlag method
+func (s *UnitSuite) TestPermissionFlag() {
+	flags, err := permissionFlag([]string{"print", "annotate"})
+	s.NoError(err)
+	s.Equal(byte(gofpdf.CnProtectPrint|gofpdf.CnProtectAnnotForms), flags)
+
+	flags, err = permissionFlag(nil)
+	s.NoError(err)
+	s.Equal(byte(0), flags)
+
+	_, err = permissionFlag([]string{"print", "extract"})
+	s.EqualError(err, "Invalid PDF permission: extract")
+}
+
+// TestDocInfoXMP method
+func (s *UnitSuite) TestDocInfoXMP() {
+	info := &docInfo{recordNumber: "2020-08-11-2", stationName: "Gagné & Sons", title: "Shift Report PDF"}
+	s.Equal("Gagné & Sons, record 2020-08-11-2", info.subject())
+
+	xmp := string(info.xmp(time.Date(2020, 8, 11, 14, 30, 0, 0, time.UTC)))
+	s.Contains(xmp, "<gsales:Station>Gagné &amp; Sons</gsales:Station>")
+	s.Contains(xmp, "<gsales:RecordNumber>2020-08-11-2</gsales:RecordNumber>")
+	s.Contains(xmp, "<xmp:CreateDate>2020-08-11T14:30:00+00:00</xmp:CreateDate>")
+	s.NotContains(xmp, "gsales:RecordDate")
+}
+
+// TestArchiveOpaque method renders a day report as archive output, the logo is flattened so there
+// is no soft mask or transparency group, as there is with the standard output of the transparent logo
+func (s *UnitSuite) TestArchiveOpaque() {
+	s.inRepoRoot()
+
+	record := &model.DayRecord{Date: "2019-12-21", StationName: "Bridge"}
+	for _, format := range []string{model.FormatStandard, model.FormatArchive} {
+		p := Init(&Options{Format: format})
+		s.NoError(p.CreateDayFile(record))
+		buf, err := p.OutputFile()
+		s.NoError(err)
+
+		out := buf.String()
+		archive := format == model.FormatArchive
+		s.Equal(!archive, strings.Contains(out, "/SMask"), format)
+		s.Equal(!archive, strings.Contains(out, "/Transparency"), format)
+		s.Equal(archive, strings.Contains(out, "/Metadata"), format)
+	}
+}
+
+// TestNewFilePageSetup method
+func (s *UnitSuite) TestNewFilePageSetup() {
+	lay := &layout{Orientation: model.OrientationPortrait, PageSize: model.PageSizeLetter}
+
+	p := Init(nil)
+	w, h := p.newFile(lay).GetPageSize()
+	s.InDelta(215.9, w, 0.1)
+	s.InDelta(279.4, h, 0.1)
+	s.InDelta(215.9-20, p.width(100), 0.1)
+
+	p = Init(&Options{Orientation: model.OrientationLandscape, PageSize: model.PageSizeA4})
+	w, h = p.newFile(lay).GetPageSize()
+	s.InDelta(297, w, 0.1)
+	s.InDelta(210, h, 0.1)
+	s.InDelta(277.0/4, p.width(25), 0.1)
+}
+
+// TestRenderWidths method renders a layout table on Letter portrait and A4 landscape, the columns
+// scale with the printable page width
+func (s *UnitSuite) TestRenderWidths() {
+	s.inRepoRoot()
+
+	record := &struct{ A, B, C string }{"a", "b", "c"}
+	lay := &layout{
+		Sections: []*section{{
+			Rows:   []*row{{Values: []*value{{Field: "A", Format: formatText, Align: "L"}, {Field: "B", Format: formatText, Align: "L"}, {Field: "C", Format: formatText, Align: "L"}}}},
+			Title:  "Widths",
+			Widths: []float64{20, 30, 50},
+		}},
+	}
+
+	for _, opts := range []*Options{
+		{Orientation: model.OrientationPortrait, PageSize: model.PageSizeLetter},
+		{Orientation: model.OrientationLandscape, PageSize: model.PageSizeA4},
+	} {
+		p := Init(opts)
+		p.newFile(lay)
+		p.file.SetCompression(false)
+		p.file.AddPage()
+		s.NoError(p.render(lay, record, nil))
+
+		xs := textXs(s, p)
+		s.Len(xs, 4) // section title and three columns
+		tw := p.tableWidth()
+		left, _, _, _ := p.file.GetMargins()
+		s.InDelta(xs[1]+0.2*tw, xs[2], 0.1)
+		s.InDelta(xs[2]+0.3*tw, xs[3], 0.1)
+		s.InDelta(left, xs[1], 0.1)
+		s.InDelta(left+tw, xs[3]+0.5*tw, 0.1)
+	}
+}
+
+// TestJournalPagination method renders enough long, wrapped journal entries to span several pages,
+// the column headings repeat on each page and the total is the sum of the entries
+func (s *UnitSuite) TestJournalPagination() {
+	s.inRepoRoot()
+
+	record := &model.ShiftRecord{}
+	var sum float64
+	for i := 1; i <= 40; i++ {
+		amount := float64(i) * 1.25
+		sum += amount
+		record.ProductAdjust = append(record.ProductAdjust, &model.NonFuelJournal{
+			Amount:      amount,
+			Comments:    strings.Repeat("Adjusted after the count was rechecked by the manager. ", 3),
+			ProductName: fmt.Sprintf("Windshield washer fluid, 4L jug %d", i),
+		})
+	}
+
+	p := Init(nil)
+	d := &Shift{pdf: p, record: record}
+	d.file = p.newFile(&layout{})
+	d.file.SetCompression(false)
+	d.file.AddPage()
+	d.setJournal()
+	s.NoError(d.file.Error())
+
+	pages := d.file.PageCount()
+	s.True(pages > 2, "pages %d", pages)
+
+	var buf bytes.Buffer
+	s.NoError(d.file.Output(&buf))
+	out := buf.String()
+	s.Equal(pages, strings.Count(out, pdfText("Product")))
+	s.Equal(1, strings.Count(out, pdfText(s.en.formatCurrency(sum))))
+	s.Equal(1, strings.Count(out, pdfText(s.en.formatCurrency(50))), "last entry")
+}
+
+// TestGeneratedText method
+func (s *UnitSuite) TestGeneratedText() {
+	tz, err := time.LoadLocation("America/Toronto")
+	s.NoError(err)
+	generated := time.Date(2020, 8, 11, 18, 30, 0, 0, time.UTC)
+
+	p := Init(&Options{Generated: generated, TimeZone: tz, User: "jdoe"})
+	s.Equal("Generated 2020-08-11 14:30 EDT by jdoe", p.generatedText())
+	s.Equal("Version dev", p.versionText())
+
+	p = Init(&Options{Generated: generated, Locale: model.LocaleFrCA, Version: "1.4.0"})
+	s.Equal("Généré le 2020-08-11 18:30 UTC", p.generatedText())
+	s.Equal("Version 1.4.0", p.versionText())
+}
+
+// inRepoRoot method changes to the repository root, where the font and image directories are, for the rest of the test
+func (s *UnitSuite) inRepoRoot() {
+	wd, err := os.Getwd()
+	s.NoError(err)
+	s.NoError(os.Chdir(".."))
+	s.T().Cleanup(func() { os.Chdir(wd) })
+}
+
+// textXs function returns the x position in mm of each text drawn, in page order
+func textXs(s *UnitSuite, p *PDF) (xs []float64) {
+	var buf bytes.Buffer
+	s.NoError(p.file.Output(&buf))
+
+	k := 72 / 25.4
+	for _, m := range textPosPattern.FindAllStringSubmatch(buf.String(), -1) {
+		x, _ := strconv.ParseFloat(m[1], 64)
+		xs = append(xs, x/k-p.file.GetCellMargin())
+	}
+	return xs
+}
+
+// pdfText function returns str as gofpdf writes it with a UTF-8 font, a UTF-16BE string
+func pdfText(str string) string {
+	var b strings.Builder
+	for _, r := range utf16.Encode([]rune(str)) {
+		b.WriteByte(byte(r >> 8))
+		b.WriteByte(byte(r))
+	}
+	return "(" + b.String() + ")"
+}
+
+// textPosPattern matches the position operands of a gofpdf text object
+var textPosPattern = regexp.MustCompile(`BT ([0-9.]+) [0-9.]+ Td`)
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
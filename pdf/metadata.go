@@ -0,0 +1,97 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Document metadata constants
+const (
+	author   = "Gales Sales Application"
+	producer = "gsales-pdf-reports"
+	xmpDate  = "2006-01-02T15:04:05-07:00"
+)
+
+// docInfo struct holds the document properties written to the info dictionary and, for
+// archive output, the XMP metadata stream
+type docInfo struct {
+	date         string
+	recordNumber string
+	stationName  string
+	title        string
+}
+
+// setDocumentInfo method sets the document properties, the creation date is the report generation time
+func (p *PDF) setDocumentInfo(info *docInfo) {
+	pdf := p.file
+
+	pdf.SetTitle(info.title, true)
+	pdf.SetAuthor(author, true)
+	pdf.SetSubject(info.subject(), true)
+	pdf.SetProducer(producer, true)
+	pdf.SetCreationDate(p.generated)
+	pdf.SetModificationDate(p.generated)
+
+	if p.archive {
+		pdf.SetXmpMetadata(info.xmp(p.generated))
+	}
+}
+
+// subject method describes the record, ie: "Bridge Street, record 2020-08-11-2"
+func (info *docInfo) subject() string {
+	parts := []string{info.stationName}
+	if info.recordNumber != "" {
+		parts = append(parts, "record "+info.recordNumber)
+	}
+	if info.date != "" {
+		parts = append(parts, info.date)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// xmp method returns the XMP metadata packet, the Dublin Core and XMP basic properties match the info
+// dictionary, station and record are recorded in the gsales namespace
+//
+// The packet does not carry a pdfaid conformance claim: gofpdf cannot write the output intent
+// PDF/A requires, so archive output follows the PDF/A rules it can (embedded fonts, XMP, no links,
+// transparency or encryption) without declaring itself conformant
+func (info *docInfo) xmp(created time.Time) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	b.WriteString("<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	b.WriteString("<rdf:Description rdf:about=\"\"\n")
+	b.WriteString("  xmlns:dc=\"http://purl.org/dc/elements/1.1/\"\n")
+	b.WriteString("  xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"\n")
+	b.WriteString("  xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"\n")
+	b.WriteString("  xmlns:gsales=\"http://www.gales.ca/ns/gsales/1.0/\">\n")
+
+	fmt.Fprintf(&b, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", escapeXML(info.title))
+	fmt.Fprintf(&b, "<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", escapeXML(author))
+	fmt.Fprintf(&b, "<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", escapeXML(info.subject()))
+	fmt.Fprintf(&b, "<xmp:CreateDate>%s</xmp:CreateDate>\n", created.Format(xmpDate))
+	fmt.Fprintf(&b, "<xmp:ModifyDate>%s</xmp:ModifyDate>\n", created.Format(xmpDate))
+	fmt.Fprintf(&b, "<pdf:Producer>%s</pdf:Producer>\n", escapeXML(producer))
+	fmt.Fprintf(&b, "<gsales:Station>%s</gsales:Station>\n", escapeXML(info.stationName))
+	if info.recordNumber != "" {
+		fmt.Fprintf(&b, "<gsales:RecordNumber>%s</gsales:RecordNumber>\n", escapeXML(info.recordNumber))
+	}
+	if info.date != "" {
+		fmt.Fprintf(&b, "<gsales:RecordDate>%s</gsales:RecordDate>\n", escapeXML(info.date))
+	}
+
+	b.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n")
+	b.WriteString("<?xpacket end=\"w\"?>")
+
+	return b.Bytes()
+}
+
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
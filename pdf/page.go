@@ -0,0 +1,79 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Page frame constants
+const (
+	footerH      = float64(4)
+	footerY      = float64(-15)
+	frameFontSz  = float64(7)
+	generatedFmt = "2006-01-02 15:04 MST"
+)
+
+// setPageFrame method registers the header and footer shared by every report
+// the header repeats the report name and subject on each page after the first, where the
+// letterhead is, and watermark, when set, is drawn beneath the page content on every page
+// the footer carries the branding footer text, when and by whom the report was generated,
+// the report type and application version, and the page number
+func (p *PDF) setPageFrame(reportName, subject string, watermark func()) {
+	pdf := p.file
+
+	pdf.SetHeaderFuncMode(func() {
+		if watermark != nil {
+			watermark()
+		}
+		if pdf.PageNo() > 1 {
+			p.setRunningHeader(reportName, subject)
+		}
+	}, false)
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(footerY)
+		p.setBrandFooter()
+		p.setMetaFooter(reportName)
+	})
+	pdf.AliasNbPages("")
+}
+
+// setRunningHeader method writes the report name and subject above the page content
+func (p *PDF) setRunningHeader(reportName, subject string) {
+	pdf := p.file
+
+	pdf.SetFont(fontFamily, "", frameFontSz)
+	p.setHeaderColor()
+	pdf.CellFormat(0, footerH, strings.Join([]string{reportName, subject}, " – "), "B", 1, "", false, 0, "")
+	pdf.Ln(2)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont(fontFamily, "", 12)
+}
+
+// setMetaFooter method writes the generation details, report type and version, and page number
+func (p *PDF) setMetaFooter(reportName string) {
+	pdf := p.file
+	tw := p.tableWidth()
+
+	pdf.SetFont(fontFamily, "I", frameFontSz)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(tw*0.45, footerH, p.generatedText(), "T", 0, "", false, 0, "")
+	pdf.CellFormat(tw*0.3, footerH, fmt.Sprintf("%s · %s", reportName, p.versionText()), "T", 0, "C", false, 0, "")
+	pdf.CellFormat(0, footerH, fmt.Sprintf(p.t("Page %d of {nb}"), pdf.PageNo()), "T", 0, "R", false, 0, "")
+}
+
+// generatedText method returns the generation timestamp, in the configured time zone, and requesting user
+func (p *PDF) generatedText() string {
+	ts := p.generated
+	if p.timeZone != nil {
+		ts = ts.In(p.timeZone)
+	}
+	if p.user == "" {
+		return fmt.Sprintf(p.t("Generated %s"), ts.Format(generatedFmt))
+	}
+	return fmt.Sprintf(p.t("Generated %s by %s"), ts.Format(generatedFmt), p.user)
+}
+
+func (p *PDF) versionText() string {
+	return fmt.Sprintf(p.t("Version %s"), firstOf(p.version, "dev"))
+}
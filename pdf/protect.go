@@ -0,0 +1,57 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// permissionFlags maps the configured permission names to the gofpdf protection flags
+var permissionFlags = map[string]byte{
+	"annotate": gofpdf.CnProtectAnnotForms,
+	"copy":     gofpdf.CnProtectCopy,
+	"modify":   gofpdf.CnProtectModify,
+	"print":    gofpdf.CnProtectPrint,
+}
+
+// protection struct
+type protection struct {
+	ownerPassword string
+	password      string
+	permissions   []string
+}
+
+// protect method encrypts the document when a user password is set, it must run before the
+// document is output
+// gofpdf uses the PDF 1.3 standard security handler, 40 bit RC4, so treat this as access
+// control against casual viewing and editing rather than strong encryption
+func (p *PDF) protect() error {
+	if p.protection == nil || p.protection.password == "" {
+		return nil
+	}
+	if p.archive {
+		return errors.New("archive output cannot be encrypted")
+	}
+
+	flags, err := permissionFlag(p.protection.permissions)
+	if err != nil {
+		return err
+	}
+	p.file.SetProtection(flags, p.protection.password, p.protection.ownerPassword)
+
+	return p.file.Error()
+}
+
+// permissionFlag function combines the named permissions, an empty list permits nothing
+// beyond opening the document
+func permissionFlag(permissions []string) (flags byte, err error) {
+	for _, name := range permissions {
+		f, ok := permissionFlags[name]
+		if !ok {
+			return 0, fmt.Errorf("Invalid PDF permission: %s", name)
+		}
+		flags |= f
+	}
+	return flags, nil
+}
@@ -2,6 +2,7 @@ package pdf
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/jung-kurt/gofpdf"
 	"github.com/pulpfree/gsales-pdf-reports/model"
@@ -12,6 +13,7 @@ type Shift struct {
 	file   *gofpdf.Fpdf
 	pdf    *PDF
 	record *model.ShiftRecord
+	verify *verification
 }
 
 func (d *Shift) create() (file *gofpdf.Fpdf, err error) {
@@ -20,187 +22,178 @@ func (d *Shift) create() (file *gofpdf.Fpdf, err error) {
 	fileNm := fmt.Sprintf("ShiftReport_%s_%s.pdf", stNm, d.record.RecordNumber)
 	d.pdf.setOutputFileName(fileNm)
 
-	d.file = gofpdf.New("P", "mm", "Letter", "")
-	titleStr := "Shift Report PDF"
-	d.file.SetTitle(titleStr, false)
-	d.file.SetAuthor("Gales Sales Application", false)
+	lay, err := loadLayout("shift", d.record)
+	if err != nil {
+		return nil, err
+	}
+
+	d.verify, err = newVerification(d.record, d.record.StationID.Hex(), "", d.record.RecordNumber, d.pdf.generated)
+	if err != nil {
+		return nil, err
+	}
 
-	d.file.SetFooterFunc(func() {
-		d.file.SetY(-15)
-		d.file.SetFont("Arial", "I", 8)
-		d.file.CellFormat(0, 10, fmt.Sprintf("Page %d of {nb}", d.file.PageNo()),
-			"", 0, "C", false, 0, "")
+	d.file = d.pdf.newFile(lay)
+	d.pdf.setDocumentInfo(&docInfo{
+		recordNumber: d.record.RecordNumber,
+		stationName:  d.record.StationName,
+		title:        d.pdf.t(lay.Title),
 	})
-	d.file.AliasNbPages("")
 
-	d.file.AddPage()
-	d.setHeader()
-	d.setSales()
-	d.setCashCards()
-	d.setOvershort()
+	var watermark func()
+	if d.isDraft() {
+		watermark = d.setWatermark
+	}
+	d.pdf.setPageFrame(d.pdf.t("Shift Report"), fmt.Sprintf("%s, %s", d.record.StationName, d.record.RecordNumber), watermark)
 
 	d.file.AddPage()
-	d.setAttendant()
-	d.setJournal()
+	err = d.pdf.render(lay, d.record, map[string]func(){
+		"header":  d.setHeader,
+		"journal": d.setJournal,
+	})
 
 	return d.file, err
 }
 
 func (d *Shift) setHeader() {
-	pdf := d.file
-	pdf.SetFont("Arial", "", 12)
-	pdf.SetFillColor(220, 220, 220)
-	pdf.Image(d.pdf.imageFile("logo.png"), 8, 7, 0, 16, false, "", 0, "http://www.gales.ca")
-	pdf.CellFormat(22, 0, " ", "", 0, "", false, 0, "")
-	pdf.SetFont("Arial", "", 20)
-	pdf.CellFormat(90, 6, "Shift Report", "0", 0, "", false, 0, "")
-
-	pdf.SetFont("Arial", "", 12)
-	pdf.CellFormat(0, 6, fmt.Sprintf("Station: %s", d.record.StationName), "0", 2, "", false, 0, "")
-	pdf.CellFormat(0, 6, fmt.Sprintf("Record: %s", d.record.RecordNumber), "0", 2, "", false, 0, "")
+	d.pdf.setQRCode(d.verify)
+	d.pdf.setLetterhead(
+		d.pdf.t("Shift Report"),
+		fmt.Sprintf(d.pdf.t("Station: %s"), d.record.StationName),
+		fmt.Sprintf(d.pdf.t("Record: %s"), d.record.RecordNumber),
+	)
+
+	if d.isDraft() {
+		d.setStatusBanner()
+	}
 }
 
-func (d *Shift) setSales() {
+// setStatusBanner method warns that the shift has not been finalised
+func (d *Shift) setStatusBanner() {
 	pdf := d.file
+
 	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, cellH, "Sales", "B", 1, "", false, 0, "")
-	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 12)
+	pdf.SetFont(fontFamily, "B", 12)
+	pdf.SetFillColor(200, 0, 0)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.CellFormat(0, cellH, d.pdf.t(draftText), "", 1, "C", true, 0, "")
+
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.SetFillColor(250, 225, 225)
+	pdf.SetTextColor(120, 0, 0)
+	status := fmt.Sprintf(d.pdf.t("Sheet Completed: %s    Overshort Checked: %s"), d.pdf.yesNo(d.record.SheetComplete), d.pdf.yesNo(d.record.OvershortComplete))
+	pdf.CellFormat(0, 6, status, "", 1, "C", true, 0, "")
+
+	d.pdf.setFillColor()
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(labelW, cellH, "Fuel", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Fuel, 2), "B", 1, "R", false, 0, "")
-
-	if d.record.OtherFuelDollar > 0 {
-		pdf.CellFormat(labelW, cellH, "Other Fuel", "B", 0, "", false, 0, "")
-		pdf.CellFormat(valueW, cellH, setFloat(d.record.OtherFuelDollar, 2), "B", 1, "R", false, 0, "")
-	}
-
-	pdf.CellFormat(labelW, cellH, "Non-Fuel", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.NonFuel, 2), "B", 1, "R", false, 0, "")
+}
 
-	pdf.CellFormat(labelW, cellH, "Fuel Adjustment", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.FuelAdjust, 2), "B", 1, "R", false, 0, "")
+// setWatermark method stamps the draft text diagonally across the page
+// it runs from the page header so it is drawn beneath the page content on every page
+// archive output has no transparency so it uses a pale solid colour instead
+func (d *Shift) setWatermark() {
+	pdf := d.file
 
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(labelW, summaryCellH, "Total", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, summaryCellH, setFloat(d.record.Total, 2), "B", 1, "R", false, 0, "")
+	w, h := pdf.GetPageSize()
+	pdf.SetFont(fontFamily, "B", 44)
+	if d.pdf.archive {
+		pdf.SetTextColor(250, 220, 220)
+	} else {
+		pdf.SetTextColor(200, 0, 0)
+		pdf.SetAlpha(0.15, "Normal")
+	}
 
-	pdf.CellFormat(labelW, summaryCellH, "Total Fuel (L)", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, summaryCellH, setFloat(d.record.Litres, 3), "B", 1, "R", false, 0, "")
+	txt := d.pdf.t(draftText)
+	pdf.TransformBegin()
+	pdf.TransformRotate(55, w/2, h/2)
+	pdf.Text((w-pdf.GetStringWidth(txt))/2, h/2, txt)
+	pdf.TransformEnd()
 
-	if d.record.OtherFuelDollar > 0 {
-		pdf.CellFormat(labelW, cellH, "Total Other Fuel (L)", "B", 0, "", false, 0, "")
-		pdf.CellFormat(valueW, cellH, setFloat(d.record.OtherFuelLitre, 3), "B", 1, "R", false, 0, "")
+	if !d.pdf.archive {
+		pdf.SetAlpha(1, "Normal")
 	}
 }
 
-func (d *Shift) setCashCards() {
+func (d *Shift) setJournal() {
 	pdf := d.file
 	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, 8, "Cash & Cards", "B", 1, "", false, 0, "")
-	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 12)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(labelW, cellH, "Visa", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Visa, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Mastercard", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Mastercard, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Gales", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Gales, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Amex", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Amex, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Discover", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Discover, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Debit", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Debit, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Diesel Discount", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.DieselDiscount, 2), "B", 1, "R", false, 0, "")
-
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(labelW, summaryCellH, "Subtotal", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, summaryCellH, setFloat(d.record.TotalCards, 2), "B", 1, "R", false, 0, "")
+	pdf.SetFont(fontFamily, "", 14)
+	d.pdf.setHeaderColor()
+	pdf.CellFormat(0, cellH, d.pdf.t("Journal Entries"), "B", 1, "", false, 0, "")
 	pdf.Ln(3)
 
-	pdf.SetFont("Arial", "", 12)
-	pdf.CellFormat(labelW, cellH, "Lottery Payout", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.LotteryPayout, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Supplier Payout", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Payout, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Cash", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Cash, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Gales Loyalty Redeemed", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.GalesLoyaltyRedeem, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Gift Certificate Redeemed", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.GiftCertRedeem, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "OS Adjust", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.OSAdjusted, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Drive Offs / NSF", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.DriveOffNSF, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Write Offs", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.WriteOff, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Other", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.Other, 2), "B", 1, "R", false, 0, "")
-
-	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(labelW, summaryCellH, "Total", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, summaryCellH, setFloat(d.record.TotalCashCards, 2), "B", 1, "R", false, 0, "")
-}
+	d.setJournalHeader()
 
-func (d *Shift) setOvershort() {
-	pdf := d.file
-	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, cellH, "Overshort", "B", 1, "", false, 0, "")
-	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 12)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(labelW, cellH, "Amount", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.OvershortAmount, 2), "B", 1, "R", false, 0, "")
-	pdf.CellFormat(0, cellH, fmt.Sprintf("%v", d.record.OvershortDescrip), "", 1, "", false, 0, "")
+	var total float64
+	for _, j := range d.record.ProductAdjust {
+		d.setJournalRow(j)
+		total += j.Amount
+	}
+
+	if d.needsPageBreak(summaryCellH) {
+		pdf.AddPage()
+		d.setJournalHeader()
+	}
+	pdf.SetFont(fontFamily, "B", 12)
+	pdf.CellFormat(d.pdf.width(journalProductPct), summaryCellH, d.pdf.t("Total"), "B", 0, "", false, 0, "")
+	d.pdf.currencyCell(d.pdf.width(journalAmountPct), summaryCellH, total, "B", 0)
+	pdf.CellFormat(0, summaryCellH, "", "B", 1, "", false, 0, "")
 }
 
-func (d *Shift) setAttendant() {
+// setJournalHeader method writes the journal column headings, repeated at the top of each new page
+func (d *Shift) setJournalHeader() {
 	pdf := d.file
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, cellH, "Attendant", "B", 1, "", false, 0, "")
-	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 12)
+	pdf.SetFont(fontFamily, "", 12)
+	d.pdf.setHeaderColor()
+
+	pdf.CellFormat(d.pdf.width(journalProductPct), cellH, d.pdf.t("Product"), "B", 0, "", false, 0, "")
+	pdf.CellFormat(d.pdf.width(journalAmountPct), cellH, d.pdf.t("Amount"), "B", 0, "R", false, 0, "")
+	pdf.CellFormat(d.pdf.width(journalGapPct), cellH, "", "B", 0, "", false, 0, "")
+	pdf.CellFormat(0, cellH, d.pdf.t("Comments"), "B", 1, "", false, 0, "")
+
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(labelW, cellH, "Name", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, d.record.AttendantName, "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Sheet Completed", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, d.record.SheetComplete, "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Overshort Checked", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, d.record.OvershortComplete, "B", 1, "R", false, 0, "")
-	pdf.CellFormat(labelW, cellH, "Overshort amount", "B", 0, "", false, 0, "")
-	pdf.CellFormat(valueW, cellH, setFloat(d.record.OvershortValue, 2), "B", 1, "R", false, 0, "")
 }
 
-func (d *Shift) setJournal() {
+// setJournalRow method writes one journal entry, wrapping product and comments within their
+// columns and sizing the row to the tallest of them
+func (d *Shift) setJournalRow(j *model.NonFuelJournal) {
 	pdf := d.file
-	pdf.Ln(headerSpacing)
-	pdf.SetFont("Arial", "", 14)
-	pdf.SetTextColor(120, 120, 120)
-	pdf.CellFormat(0, cellH, "Journal Entries", "B", 1, "", false, 0, "")
-	pdf.Ln(3)
-	pdf.SetFont("Arial", "", 12)
-
-	pdf.CellFormat(float64(50), cellH, "Product", "B", 0, "", false, 0, "")
-	pdf.CellFormat(float64(20), cellH, "Amount", "B", 0, "R", false, 0, "")
-	pdf.CellFormat(float64(10), cellH, "", "B", 0, "", false, 0, "")
-	pdf.CellFormat(0, cellH, "Comments", "B", 1, "", false, 0, "")
+	pdf.SetFont(fontFamily, "", 12)
+
+	productW := d.pdf.width(journalProductPct)
+	amountW := d.pdf.width(journalAmountPct)
+	gapW := d.pdf.width(journalGapPct)
+	commentsW := d.pdf.tableWidth() - productW - amountW - gapW
+	lines := len(pdf.SplitText(j.ProductName, productW))
+	if n := len(pdf.SplitText(j.Comments, commentsW)); n > lines {
+		lines = n
+	}
+	rowH := math.Max(cellH, float64(lines)*journalLineH+2*journalPadding)
 
-	pdf.SetTextColor(0, 0, 0)
-	for _, j := range d.record.ProductAdjust {
-		pdf.CellFormat(float64(50), cellH, j.ProductName, "B", 0, "", false, 0, "")
-		pdf.CellFormat(float64(20), cellH, setFloat(j.Amount, 2), "B", 0, "R", false, 0, "")
-		pdf.CellFormat(float64(10), cellH, "", "B", 0, "", false, 0, "")
-		pdf.CellFormat(0, cellH, j.Comments, "B", 1, "", false, 0, "")
+	if d.needsPageBreak(rowH) {
+		pdf.AddPage()
+		d.setJournalHeader()
 	}
+
+	x, y := pdf.GetXY()
+	pdf.SetXY(x, y+journalPadding)
+	pdf.MultiCell(productW, journalLineH, j.ProductName, "", "L", false)
+	pdf.SetXY(x+productW, y+journalPadding)
+	d.pdf.currencyCell(amountW, journalLineH, j.Amount, "", 0)
+	pdf.SetXY(x+productW+amountW+gapW, y+journalPadding)
+	pdf.MultiCell(commentsW, journalLineH, j.Comments, "", "L", false)
+
+	pdf.Line(x, y+rowH, x+d.pdf.tableWidth(), y+rowH)
+	pdf.SetXY(x, y+rowH)
+}
+
+// needsPageBreak method reports whether a row of height h would run into the bottom margin
+func (d *Shift) needsPageBreak(h float64) bool {
+	_, pageH := d.file.GetPageSize()
+	_, bottom := d.file.GetAutoPageBreak()
+	return d.file.GetY()+h > pageH-bottom
+}
+
+// isDraft method reports whether the shift sheet or overshort has yet to be finalised
+func (d *Shift) isDraft() bool {
+	return !d.record.SheetComplete || !d.record.OvershortComplete
 }
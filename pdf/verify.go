@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boombuler/barcode/qr"
+)
+
+// QR code constants
+const (
+	qrHashLen = 32 // hex characters of the record hash carried in the payload
+	qrSize    = float64(20)
+	qrTop     = float64(5)
+	// qrPrefix marks the compact payload used when no verification URL is configured
+	qrPrefix = "GSR1"
+)
+
+// verification struct identifies the record and data version a report was generated from
+type verification struct {
+	date         string
+	generated    time.Time
+	hash         string
+	recordNumber string
+	stationID    string
+}
+
+// newVerification function hashes record, the hash changes whenever any value on the report does
+func newVerification(record interface{}, stationID, date, recordNumber string, generated time.Time) (*verification, error) {
+	hash, err := recordHash(record)
+	if err != nil {
+		return nil, err
+	}
+	return &verification{
+		date:         date,
+		generated:    generated,
+		hash:         hash,
+		recordNumber: recordNumber,
+		stationID:    stationID,
+	}, nil
+}
+
+// payload method returns the verification URL, or the compact pipe separated payload when verifyURL is empty
+func (v *verification) payload(verifyURL string) (string, error) {
+	ts := strconv.FormatInt(v.generated.Unix(), 10)
+
+	if verifyURL == "" {
+		key := v.recordNumber
+		if key == "" {
+			key = v.date
+		}
+		return strings.Join([]string{qrPrefix, v.stationID, key, ts, v.hash}, "|"), nil
+	}
+
+	u, err := url.Parse(verifyURL)
+	if err != nil {
+		return "", fmt.Errorf("Invalid verification URL: %s", err.Error())
+	}
+	q := u.Query()
+	q.Set("station", v.stationID)
+	if v.recordNumber != "" {
+		q.Set("record", v.recordNumber)
+	} else {
+		q.Set("date", v.date)
+	}
+	q.Set("generated", ts)
+	q.Set("hash", v.hash)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// setQRCode method draws the verification QR code in the top right corner of the page
+// with the short form of the record hash beneath it
+func (p *PDF) setQRCode(v *verification) {
+	pdf := p.file
+
+	payload, err := v.payload(p.verifyURL)
+	if err != nil {
+		pdf.SetError(err)
+		return
+	}
+	code, err := qr.Encode(payload, qr.M, qr.Auto)
+	if err != nil {
+		pdf.SetError(err)
+		return
+	}
+
+	pageW, _ := pdf.GetPageSize()
+	_, _, right, _ := pdf.GetMargins()
+	x := pageW - right - qrSize
+
+	// modules are drawn as rectangles so the code stays sharp at any print size
+	bounds := code.Bounds()
+	module := qrSize / float64(bounds.Dx())
+	x0, y0 := pdf.GetXY()
+	pdf.SetFillColor(0, 0, 0)
+	for row := bounds.Min.Y; row < bounds.Max.Y; row++ {
+		for col := bounds.Min.X; col < bounds.Max.X; col++ {
+			if r, _, _, _ := code.At(col, row).RGBA(); r == 0 {
+				pdf.Rect(x+float64(col-bounds.Min.X)*module, qrTop+float64(row-bounds.Min.Y)*module, module, module, "F")
+			}
+		}
+	}
+
+	pdf.SetFont(fontFamily, "", 6)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetXY(x, qrTop+qrSize)
+	pdf.CellFormat(qrSize, 3, v.hash[:8], "", 0, "C", false, 0, "")
+
+	p.setFillColor()
+	pdf.SetFont(fontFamily, "", 12)
+	pdf.SetXY(x0, y0)
+}
+
+// recordHash function returns the truncated SHA-256 of the JSON encoded record
+func recordHash(record interface{}) (string, error) {
+	js, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(js)
+	return hex.EncodeToString(sum[:])[:qrHashLen], nil
+}
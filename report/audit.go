@@ -0,0 +1,73 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// ======================== Un-exported Methods ================================================ //
+
+// audit method records the report generation, with the error if it failed
+// without a database connection, or when the insert fails, the outcome is logged instead
+func (r *Report) audit(start time.Time, genErr error) (err error) {
+
+	a := &model.ReportAudit{
+		ContentHash: r.contentHash,
+		CreatedAt:   start,
+		DurationMs:  time.Since(start).Milliseconds(),
+		OutputKey:   r.outputKey,
+		Principal:   r.principal,
+		Request:     r.auditRequest(),
+		StationID:   r.stationID,
+		Success:     genErr == nil,
+	}
+	if genErr != nil {
+		a.Error = genErr.Error()
+	}
+
+	if r.db == nil {
+		err = errors.New("no database connection")
+	} else {
+		err = r.db.InsertAudit(a)
+	}
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":     a.Error,
+			"principal": r.principal.Name(),
+			"success":   a.Success,
+		}).Errorf("failed to record audit for %s report, station %s: %s", a.Request.ReportType, r.stationID.Hex(), err.Error())
+	}
+
+	return err
+}
+
+func (r *Report) auditRequest() *model.ReportAuditRequest {
+	req := &model.ReportAuditRequest{
+		Format:       r.format,
+		Locale:       r.locale,
+		Orientation:  r.orientation,
+		PageSize:     r.pageSize,
+		Protected:    r.password != "",
+		RecordNumber: r.recordNumber,
+		ReportType:   r.reportType.String(),
+		StationName:  r.stationName,
+	}
+	if *r.reportType == model.DayReport {
+		req.Date = r.date.Format(timeFormatLong)
+	}
+	return req
+}
+
+// ======================== Helper Functions =================================================== //
+
+// contentHash function returns the hex encoded SHA-256 of the output file
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
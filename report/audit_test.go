@@ -0,0 +1,171 @@
+package report
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/config"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const bridgeID = "56cf1815982d82b0f3000001"
+
+// auditDB struct stubs the station directory and audit log, other DBHandler methods are not used
+type auditDB struct {
+	model.DBHandler
+	audits    []*model.ReportAudit
+	closed    bool
+	insertErr error
+	stations  []*model.Station
+}
+
+// Close method
+func (db *auditDB) Close() {
+	db.closed = true
+}
+
+// GetStationByName method
+func (db *auditDB) GetStationByName(name string) (*model.Station, error) {
+	for _, st := range db.stations {
+		if st.Name == name {
+			return st, nil
+		}
+	}
+	return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Caller: "db.GetStationByName", Msg: "No station found matching name"})
+}
+
+// InsertAudit method
+func (db *auditDB) InsertAudit(a *model.ReportAudit) error {
+	if db.insertErr != nil {
+		return db.insertErr
+	}
+	db.audits = append(db.audits, a)
+	return nil
+}
+
+// AuditSuite struct
+type AuditSuite struct {
+	suite.Suite
+	bridge primitive.ObjectID
+	cfg    *config.Config
+	db     *auditDB
+}
+
+// SetupTest method
+func (s *AuditSuite) SetupTest() {
+	s.bridge, _ = primitive.ObjectIDFromHex(bridgeID)
+	s.db = &auditDB{stations: []*model.Station{{ID: s.bridge, Name: "Bridge"}}}
+
+	s.cfg = &config.Config{}
+	s.cfg.Authorization = &config.Authorization{
+		Groups: map[string]*config.GroupAccess{
+			"bridge-managers": {ReportTypes: []string{"day", "shift"}, Stations: []string{bridgeID}},
+		},
+	}
+	s.cfg.Protection = map[string]*config.Protection{
+		"shift": {Permissions: []string{"print"}, Required: true},
+	}
+}
+
+func (s *AuditSuite) request(rt model.ReportType, groups ...string) *model.ReportRequest {
+	return &model.ReportRequest{
+		Format:      model.FormatStandard,
+		Principal:   &model.Principal{Groups: groups, Username: "jdoe"},
+		ReportType:  &rt,
+		StationName: "Bridge",
+	}
+}
+
+// prepare method readies the report for req on the stub database, as New does once connected
+func (s *AuditSuite) prepare(req *model.ReportRequest) (*Report, error) {
+	r := newReport(req, s.cfg)
+	r.db = s.db
+	if err := r.prepare(req, time.Now()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TestGenerateAudit method checks the success or error of every generation is audited
+// and the output is withheld when the audit of a successful generation can't be saved
+func (s *AuditSuite) TestGenerateAudit() {
+	rt := model.DayReport
+	r := &Report{db: s.db, reportType: &rt, stationID: s.bridge}
+
+	out, err := r.generate(func() (string, error) { return "https://signed", nil })
+	s.NoError(err)
+	s.Equal("https://signed", out)
+	s.True(s.db.closed)
+	s.Len(s.db.audits, 1)
+	s.True(s.db.audits[0].Success)
+	s.Equal("", s.db.audits[0].Error)
+
+	genErr := errors.New("no records found")
+	_, err = r.generate(func() (string, error) { return "", genErr })
+	s.Equal(genErr, err)
+	s.Len(s.db.audits, 2)
+	s.False(s.db.audits[1].Success)
+	s.Equal("no records found", s.db.audits[1].Error)
+
+	s.db.insertErr = errors.New("write concern")
+	out, err = r.generate(func() (string, error) { return "https://signed", nil })
+	s.Equal(s.db.insertErr, err)
+	s.Equal("", out, "url withheld")
+
+	_, err = r.generate(func() (string, error) { return "", genErr })
+	s.Equal(genErr, err, "generation error kept over audit error")
+}
+
+// TestPrepareAudit method checks a request refused before generation is audited and the database closed
+func (s *AuditSuite) TestPrepareAudit() {
+
+	// station name not found
+	req := s.request(model.DayReport, "bridge-managers")
+	req.StationName = "No Such Station"
+	_, err := s.prepare(req)
+	s.Equal(apierr.CodeNotFound, apierr.GetCode(err))
+	s.Len(s.db.audits, 1)
+	s.False(s.db.audits[0].Success)
+	s.Equal("No Such Station", s.db.audits[0].Request.StationName)
+	s.True(s.db.closed)
+
+	// forbidden, audited against the resolved station
+	s.db.closed = false
+	_, err = s.prepare(s.request(model.DayReport, "collier-staff"))
+	s.Equal(apierr.CodeUnauthorized, apierr.GetCode(err))
+	s.Len(s.db.audits, 2)
+	s.Equal(s.bridge, s.db.audits[1].StationID)
+	s.Equal("jdoe", s.db.audits[1].Principal.Name())
+	s.True(s.db.closed)
+
+	// protection required without a password
+	_, err = s.prepare(s.request(model.ShiftReport, "bridge-managers"))
+	s.Equal(apierr.CodeValidation, apierr.GetCode(err))
+	s.Len(s.db.audits, 3)
+	s.Equal("shift", s.db.audits[2].Request.ReportType)
+
+	// allowed, audited once generated
+	s.db.closed = false
+	r, err := s.prepare(s.request(model.DayReport, "bridge-managers"))
+	s.NoError(err)
+	s.Equal(s.bridge, r.stationID)
+	s.Len(s.db.audits, 3)
+	s.False(s.db.closed)
+}
+
+// TestAuditWithoutDB method checks an audit without a database connection is logged, not saved
+func (s *AuditSuite) TestAuditWithoutDB() {
+	rt := model.DayReport
+	r := &Report{reportType: &rt, stationName: "Bridge"}
+	s.Error(r.audit(time.Now(), errors.New("connection refused")))
+}
+
+// TestAuditSuite function
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(AuditSuite))
+}
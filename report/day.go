@@ -26,6 +26,11 @@ func (r *Day) GetRecord() (*model.DayRecord, error) {
 		return nil, err
 	}
 
+	err = r.reconcile()
+	if err != nil {
+		return nil, err
+	}
+
 	return r.record, nil
 }
 
@@ -58,6 +63,7 @@ func (r *Day) setRecord() (err error) {
 		Fuel5Dollar: model.SetFloat(day["fuel_5_dollar"]),
 		Fuel5Litre:  model.SetFloat(day["fuel_5_litre"]),
 		Fuel6Dollar: model.SetFloat(day["fuel_6_dollar"]),
+		Fuel6Litre:  model.SetFloat(day["fuel_6_litre"]),
 		TotalDollar: model.SetFloat(day["total_fuelDollar"]),
 		TotalLitre:  model.SetFloat(day["total_fuelLitre"]),
 	}
@@ -72,6 +78,7 @@ func (r *Day) setRecord() (err error) {
 		Debit:          model.SetFloat(day["cash_debit"]),
 		DieselDiscount: model.SetFloat(day["cash_dieselDiscount"]),
 	}
+	cc.TotalCards = sumCards(cc)
 
 	// cash values
 	cash := model.CashFields{
@@ -85,10 +92,12 @@ func (r *Day) setRecord() (err error) {
 		OSAdjusted:         model.SetFloat(day["cash_osAdjusted"]),
 		WriteOff:           model.SetFloat(day["cash_writeOff"]),
 	}
+	cash.TotalCash = sumCash(cash)
 
 	// summary values
 	sum := model.DaySummary{
 		NonFuel:        model.SetFloat(day["total_nonFuel"]),
+		Overshort:      model.SetFloat(day["overshort"]),
 		Total:          model.SetFloat(day["total_sales"]),
 		TotalCashCards: model.SetFloat(day["total_cashAndCC"]),
 	}
@@ -3,8 +3,13 @@ package report
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"path"
 	"time"
 
+	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/auth"
 	"github.com/pulpfree/gsales-pdf-reports/awsservices"
 	"github.com/pulpfree/gsales-pdf-reports/config"
 	"github.com/pulpfree/gsales-pdf-reports/model"
@@ -16,13 +21,22 @@ import (
 // Report struct
 type Report struct {
 	cfg          *config.Config
+	contentHash  string
 	date         time.Time
 	db           model.DBHandler
 	file         *pdf.PDF
 	filename     string
+	format       string
+	locale       string
+	orientation  string
+	outputKey    string
+	pageSize     string
+	password     string
+	principal    *model.Principal
 	recordNumber string
 	reportType   *model.ReportType
 	stationID    primitive.ObjectID
+	stationName  string
 }
 
 // Constants
@@ -31,29 +45,119 @@ const (
 	tmpDir         = "../tmp"
 )
 
-// New function
+// builder func creates the file of a report type
+type builder func(*Report) error
+
+// builders maps each registered report type to its builder
+var builders = map[model.ReportType]builder{}
+
+func init() {
+	registerBuilder(model.DayReport, (*Report).createDayReport)
+	registerBuilder(model.ShiftReport, (*Report).createShiftReport)
+}
+
+// registerBuilder function adds the builder of a report type registered with model.RegisterReportType
+// an unregistered type, or registering a type twice, panics as with a duplicate init
+func registerBuilder(rt model.ReportType, build builder) {
+	if _, ok := model.LookupReportType(rt); !ok {
+		panic(fmt.Sprintf("report: builder for unregistered report type %d", rt))
+	}
+	if _, ok := builders[rt]; ok {
+		panic("report: builder registered twice: " + rt.String())
+	}
+	builders[rt] = build
+}
+
+// New function opens the database and prepares the report, a request refused here is audited as a failed generation
 func New(req *model.ReportRequest, cfg *config.Config) (report *Report, err error) {
-	db, err := db.NewDB(cfg.GetMongoConnectURL(), cfg.DBName)
+	start := time.Now()
+	report = newReport(req, cfg)
+
+	mdb, err := db.NewDB(cfg.GetMongoConnectURL(), cfg.DBName)
 	if err != nil {
+		// without a connection the audit record can only be logged
+		report.audit(start, err)
 		return nil, err
 	}
+	report.db = mdb
 
-	report = &Report{
-		cfg:          cfg,
-		date:         req.Date,
-		db:           db,
-		recordNumber: req.RecordNumber,
-		reportType:   req.ReportType,
-		stationID:    req.StationID,
+	if err = report.prepare(req, start); err != nil {
+		return nil, err
 	}
 
-	return report, err
+	return report, nil
 }
 
 // ===================== Exported Methods ====================================================== //
 
 // CreateSignedURL method
+// every generation is audited, the URL is withheld when the audit record can't be saved
 func (r *Report) CreateSignedURL() (url string, err error) {
+	return r.generate(r.createSignedURL)
+}
+
+// SaveToDisk method
+func (r *Report) SaveToDisk() (err error) {
+	_, err = r.generate(func() (string, error) {
+		return "", r.saveToDisk()
+	})
+	return err
+}
+
+// ===================== Un-exported Methods =================================================== //
+
+// prepare method checks the report may be generated for the request, a refused request
+// is audited and the database closed
+func (r *Report) prepare(req *model.ReportRequest, start time.Time) (err error) {
+	if err = r.check(req); err != nil {
+		r.audit(start, err)
+		r.db.Close()
+	}
+	return err
+}
+
+// check method resolves a station requested by name to its id from the station directory, grants are by id
+// so the principal is authorized only once it is known, and before any sales records are read
+func (r *Report) check(req *model.ReportRequest) (err error) {
+	if req.StationID.IsZero() {
+		station, err := r.db.GetStationByName(req.StationName)
+		if err != nil {
+			return err
+		}
+		req.StationID = station.ID
+		r.stationID = station.ID
+	}
+	if err = auth.Authorize(req.Principal, req, r.cfg); err != nil {
+		return err
+	}
+
+	if r.cfg.GetProtection(*req.ReportType).Required {
+		if req.Format == model.FormatArchive {
+			return apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: "archive output cannot be encrypted", Caller: "report.New", Msg: "Error invalid input.Format"})
+		}
+		if req.Password == "" {
+			return apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: "empty input.Password", Caller: "report.New", Msg: "Error missing input.Password"})
+		}
+	}
+
+	return nil
+}
+
+// generate method runs gen, audits its success or error and closes the database
+// the output is withheld when the audit record of a successful generation can't be saved
+func (r *Report) generate(gen func() (string, error)) (out string, err error) {
+	start := time.Now()
+	defer r.db.Close()
+
+	out, err = gen()
+	if auditErr := r.audit(start, err); auditErr != nil && err == nil {
+		return "", auditErr
+	}
+
+	return out, err
+}
+
+func (r *Report) createSignedURL() (url string, err error) {
 	err = r.create()
 	if err != nil {
 		return url, err
@@ -64,42 +168,72 @@ func (r *Report) CreateSignedURL() (url string, err error) {
 	if err != nil {
 		return "", err
 	}
+	r.contentHash = contentHash(fileOutput.Bytes())
+
+	sig, err := r.sign(fileOutput.Bytes())
+	if err != nil {
+		return "", err
+	}
 
 	filePrefix := r.file.OutputFileName
 	s3Service, err := awsservices.NewS3(r.cfg)
+	if err != nil {
+		return "", err
+	}
 
+	if sig != nil {
+		if _, err = s3Service.PutSignature(filePrefix+signatureExt, bytes.NewBuffer(sig)); err != nil {
+			return "", err
+		}
+	}
+
+	r.outputKey = filePrefix
 	return s3Service.GetSignedURL(filePrefix, &fileOutput)
 }
 
-// SaveToDisk method
-func (r *Report) SaveToDisk() (err error) {
+func (r *Report) saveToDisk() (err error) {
 
 	err = r.create()
 	if err != nil {
 		return err
 	}
 
-	err = r.file.OutputToDisk(tmpDir)
+	fileOutput, err := r.file.OutputFile()
+	if err != nil {
+		return err
+	}
+	r.contentHash = contentHash(fileOutput.Bytes())
+
+	sig, err := r.sign(fileOutput.Bytes())
+	if err != nil {
+		return err
+	}
+
+	fp := path.Join(tmpDir, r.file.OutputFileName)
+	if err = ioutil.WriteFile(fp, fileOutput.Bytes(), 0644); err != nil {
+		return err
+	}
+	r.outputKey = fp
+	if sig != nil {
+		err = ioutil.WriteFile(fp+signatureExt, sig, 0644)
+	}
 
 	return err
 }
 
-// ===================== Un-exported Methods =================================================== //
-
-// create method
+// create method builds the file with the builder registered for the report type
 func (r *Report) create() (err error) {
 
 	r.setFileName()
 
 	rt := *r.reportType
-	switch rt {
-	case model.DayReport:
-		return r.createDayReport()
-	case model.ShiftReport:
-		return r.createShiftReport()
+	build, ok := builders[rt]
+	if _, registered := model.LookupReportType(rt); !registered || !ok {
+		errStr := fmt.Sprintf("no builder registered for report type %d", rt)
+		return apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: errStr, Caller: "report.create", Msg: "Error missing or invalid input.ReportType"})
 	}
 
-	return err
+	return build(r)
 }
 
 // createDayReport method
@@ -115,9 +249,12 @@ func (r *Report) createDayReport() (err error) {
 	if err != nil {
 		return err
 	}
-	defer r.db.Close()
+	opts, err := r.pdfOptions()
+	if err != nil {
+		return err
+	}
 
-	r.file = pdf.Init()
+	r.file = pdf.Init(opts)
 	err = r.file.CreateDayFile(record)
 
 	return err
@@ -136,9 +273,12 @@ func (r *Report) createShiftReport() (err error) {
 	if err != nil {
 		return err
 	}
-	defer r.db.Close()
+	opts, err := r.pdfOptions()
+	if err != nil {
+		return err
+	}
 
-	r.file = pdf.Init()
+	r.file = pdf.Init(opts)
 	err = r.file.CreateShiftFile(record)
 
 	return err
@@ -159,3 +299,56 @@ func (r *Report) setFileName() {
 func (r *Report) getFileName() string {
 	return r.filename
 }
+
+func (r *Report) pdfOptions() (*pdf.Options, error) {
+	branding, err := r.branding()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pdf.Options{
+		Branding:      branding,
+		Format:        r.format,
+		Generated:     time.Now(),
+		Locale:        r.locale,
+		Orientation:   r.orientation,
+		PageSize:      r.pageSize,
+		OwnerPassword: r.cfg.OwnerPassword,
+		Password:      r.password,
+		Permissions:   r.cfg.GetProtection(*r.reportType).Permissions,
+		TimeZone:      r.cfg.TimeZone,
+		User:          r.principal.Name(),
+		VerifyURL:     r.cfg.VerifyURL,
+		Version:       config.Version,
+	}, nil
+}
+
+// branding method resolves the station letterhead, a profile on the station record takes
+// precedence over the configured profile for the station, which in turn overrides the default
+func (r *Report) branding() (*model.Branding, error) {
+	station, err := r.db.GetStation(r.stationID)
+	if err != nil {
+		return nil, err
+	}
+	return model.MergeBranding(station.Branding, r.cfg.GetBranding(r.stationID.Hex())), nil
+}
+
+// ===================== Helper Functions ====================================================== //
+
+// newReport function returns the report for the request, without a database connection
+func newReport(req *model.ReportRequest, cfg *config.Config) *Report {
+	return &Report{
+		cfg:          cfg,
+		date:         req.Date,
+		format:       req.Format,
+		locale:       req.Locale,
+		orientation:  req.Orientation,
+		pageSize:     req.PageSize,
+		password:     req.Password,
+		principal:    req.Principal,
+		recordNumber: req.RecordNumber,
+		reportType:   req.ReportType,
+		stationID:    req.StationID,
+		stationName:  req.StationName,
+	}
+}
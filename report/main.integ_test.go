@@ -166,6 +166,27 @@ func (s *IntegSuite) TestDayRecord() {
 	s.NotNil(record)
 }
 
+// TestDayReconcile method
+func (s *IntegSuite) TestDayReconcile() {
+	var err error
+
+	s.report, _ = New(s.dayReportReq, cfg)
+	r := &Day{
+		date:      s.report.date,
+		db:        s.report.db,
+		stationID: s.report.stationID,
+	}
+	err = r.setRecord()
+	s.NoError(err)
+
+	err = r.reconcile()
+	s.NoError(err)
+	s.True(r.record.ShiftCount > 0)
+	for _, d := range r.record.Discrepancies {
+		s.InDelta(d.DayValue-d.ShiftValue, d.Difference, reconcileTolerance)
+	}
+}
+
 // TestShiftRecord method
 func (s *IntegSuite) TestShiftRecord() {
 	var err error
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// BuilderSuite struct
+type BuilderSuite struct {
+	suite.Suite
+}
+
+// TestBuildersMatchReportTypes method checks every registered report type has a builder
+func (s *BuilderSuite) TestBuildersMatchReportTypes() {
+	defs := model.ReportTypes()
+	s.Len(builders, len(defs))
+	for _, def := range defs {
+		s.Contains(builders, def.Type, def.Name)
+	}
+}
+
+// TestCreateUnknownType method checks a report type without a builder is rejected
+func (s *BuilderSuite) TestCreateUnknownType() {
+	rt := model.ReportType(99)
+	r := &Report{reportType: &rt}
+
+	err := r.create()
+	s.Error(err)
+	s.Equal(apierr.CodeValidation, apierr.GetCode(err))
+	s.Nil(r.file)
+}
+
+// TestBuilderSuite function
+func TestBuilderSuite(t *testing.T) {
+	suite.Run(t, new(BuilderSuite))
+}
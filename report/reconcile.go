@@ -0,0 +1,135 @@
+package report
+
+import (
+	"math"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// reconcileTolerance is the largest difference (half a cent) accepted between day and shift totals
+const reconcileTolerance = 0.005
+
+// reconcileField struct
+type reconcileField struct {
+	name  string
+	day   float64
+	shift float64
+	unit  string // defaults to model.UnitDollar
+}
+
+// ======================== Un-exported Methods ================================================ //
+
+// reconcile method loads every shift for the day, sums them the same way Shift.setRecord
+// derives a shift record and attaches any field that differs from the day aggregation
+// the day report is still produced when the shifts can't be loaded, the failure is noted on the record
+func (r *Day) reconcile() (err error) {
+
+	shifts, err := r.db.GetShifts(r.date, r.stationID)
+	if err != nil {
+		log.Errorf("day report for station %s on %s not reconciled: %s", r.stationID.Hex(), r.record.Date, err)
+		r.record.ReconcileError = err.Error()
+		return nil
+	}
+
+	var cc model.CardFields
+	var cash model.CashFields
+	var sum model.ShiftSummary
+	var overshort float64
+	for _, shift := range shifts {
+		scc := setCardFields(shift)
+		scash := setCashFields(shift)
+		ssum := setShiftSummary(shift, scc, scash)
+
+		cc.Amex += scc.Amex
+		cc.Debit += scc.Debit
+		cc.DieselDiscount += scc.DieselDiscount
+		cc.Discover += scc.Discover
+		cc.Gales += scc.Gales
+		cc.Mastercard += scc.Mastercard
+		cc.Visa += scc.Visa
+		cc.TotalCards += scc.TotalCards
+
+		cash.Cash += scash.Cash
+		cash.DriveOffNSF += scash.DriveOffNSF
+		cash.GalesLoyaltyRedeem += scash.GalesLoyaltyRedeem
+		cash.GiftCertRedeem += scash.GiftCertRedeem
+		cash.LotteryPayout += scash.LotteryPayout
+		cash.OSAdjusted += scash.OSAdjusted
+		cash.Other += scash.Other
+		cash.Payout += scash.Payout
+		cash.WriteOff += scash.WriteOff
+		cash.TotalCash += scash.TotalCash
+
+		sum.Fuel += ssum.Fuel
+		sum.Litres += ssum.Litres
+		sum.NonFuel += ssum.NonFuel
+		sum.Total += ssum.Total
+		sum.TotalCashCards += ssum.TotalCashCards
+
+		if shift.Overshort != nil {
+			overshort += shift.Overshort.Amount
+		}
+	}
+
+	day := r.record
+	fields := []reconcileField{
+		{name: "Visa", day: day.Visa, shift: cc.Visa},
+		{name: "Mastercard", day: day.Mastercard, shift: cc.Mastercard},
+		{name: "Gales", day: day.Gales, shift: cc.Gales},
+		{name: "Amex", day: day.Amex, shift: cc.Amex},
+		{name: "Discover", day: day.Discover, shift: cc.Discover},
+		{name: "Debit", day: day.Debit, shift: cc.Debit},
+		{name: "Diesel Discount", day: day.DieselDiscount, shift: cc.DieselDiscount},
+		{name: "Total Cards", day: day.TotalCards, shift: cc.TotalCards},
+		{name: "Cash", day: day.Cash, shift: cash.Cash},
+		{name: "Drive Offs / NSF", day: day.DriveOffNSF, shift: cash.DriveOffNSF},
+		{name: "Gales Loyalty Redeemed", day: day.GalesLoyaltyRedeem, shift: cash.GalesLoyaltyRedeem},
+		{name: "Gift Certificate Redeemed", day: day.GiftCertRedeem, shift: cash.GiftCertRedeem},
+		{name: "Lottery Payout", day: day.LotteryPayout, shift: cash.LotteryPayout},
+		{name: "OS Adjusted", day: day.OSAdjusted, shift: cash.OSAdjusted},
+		{name: "Other", day: day.Other, shift: cash.Other},
+		{name: "Supplier Payout", day: day.Payout, shift: cash.Payout},
+		{name: "Write Offs", day: day.WriteOff, shift: cash.WriteOff},
+		{name: "Total Cash", day: day.TotalCash, shift: cash.TotalCash},
+		{name: "Total Fuel", day: day.TotalDollar, shift: sum.Fuel},
+		{name: "Total Fuel (L)", day: day.TotalLitre, shift: sum.Litres, unit: model.UnitLitre},
+		{name: "Non-Fuel", day: day.NonFuel, shift: sum.NonFuel},
+		{name: "Total Sales", day: day.Total, shift: sum.Total},
+		{name: "Total Cash & Cards", day: day.TotalCashCards, shift: sum.TotalCashCards},
+		{name: "Overshort", day: day.Overshort, shift: overshort},
+	}
+
+	day.ShiftCount = len(shifts)
+	day.Discrepancies = compareFields(fields)
+	if len(day.Discrepancies) > 0 {
+		log.Warnf("day report for station %s on %s has %d discrepancies against %d shifts", r.stationID.Hex(), day.Date, len(day.Discrepancies), day.ShiftCount)
+	}
+
+	return err
+}
+
+// ======================== Helper Functions =================================================== //
+
+// compareFields returns a Discrepancy for every field whose day and shift values diverge
+func compareFields(fields []reconcileField) (ds []*model.Discrepancy) {
+	for _, f := range fields {
+		diff := f.day - f.shift
+		if math.Abs(diff) < reconcileTolerance {
+			continue
+		}
+		unit := f.unit
+		if unit == "" {
+			unit = model.UnitDollar
+		}
+		ds = append(ds, &model.Discrepancy{
+			DayValue:   f.day,
+			Difference: diff,
+			Field:      f.name,
+			ShiftValue: f.shift,
+			Unit:       unit,
+		})
+	}
+	return ds
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// ReconcileSuite struct
+type ReconcileSuite struct {
+	suite.Suite
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// TestReconcileIncompleteShift method checks a shift missing its card, cash, summary and overshort
+// subdocuments is reconciled as zeros
+func (s *ReconcileSuite) TestReconcileIncompleteShift() {
+	complete := &model.Sales{
+		Cash:       &model.Cash{Bills: floatPtr(100), Debit: floatPtr(50)},
+		CreditCard: &model.CreditCard{Visa: floatPtr(25)},
+		Overshort:  &model.Overshort{Amount: -1.5},
+		RecordNum:  "2019-12-21-1",
+		Summary:    &model.SalesSummary{FuelDollar: 150, FuelLitre: 120.5, TotalSales: 175},
+	}
+	incomplete := &model.Sales{RecordNum: "2019-12-21-2"}
+
+	rep := &Day{
+		db: &shiftsDB{shifts: []*model.Sales{complete, incomplete}},
+		record: &model.DayRecord{
+			CardFields:  model.CardFields{Debit: 50, TotalCards: 75, Visa: 25},
+			CashFields:  model.CashFields{Cash: 100, TotalCash: 100},
+			DaySummary:  model.DaySummary{Overshort: -1.5, Total: 200, TotalCashCards: 175},
+			FuelSummary: model.FuelSummary{TotalDollar: 150, TotalLitre: 120.5},
+		},
+	}
+	s.NotPanics(func() { s.NoError(rep.reconcile()) })
+	s.Equal(2, rep.record.ShiftCount)
+	s.Equal("", rep.record.ReconcileError)
+	s.Len(rep.record.Discrepancies, 1)
+	s.Equal("Total Sales", rep.record.Discrepancies[0].Field)
+	s.Equal(model.UnitDollar, rep.record.Discrepancies[0].Unit)
+	s.InDelta(25, rep.record.Discrepancies[0].Difference, 0.001)
+}
+
+// TestCompareFieldsUnit method checks fuel volume discrepancies are in litres
+func (s *ReconcileSuite) TestCompareFieldsUnit() {
+	ds := compareFields([]reconcileField{
+		{name: "Total Fuel", day: 150, shift: 140},
+		{name: "Total Fuel (L)", day: 120.5, shift: 110.25, unit: model.UnitLitre},
+	})
+	s.Len(ds, 2)
+	s.Equal(model.UnitDollar, ds[0].Unit)
+	s.Equal(model.UnitLitre, ds[1].Unit)
+	s.InDelta(10.25, ds[1].Difference, 0.001)
+}
+
+// TestReconcileShiftsError method checks a failure loading the shifts is noted on the record, not returned
+func (s *ReconcileSuite) TestReconcileShiftsError() {
+	rep := &Day{
+		db:     &shiftsDB{err: context.DeadlineExceeded},
+		record: &model.DayRecord{},
+	}
+	s.NoError(rep.reconcile())
+	s.Equal(context.DeadlineExceeded.Error(), rep.record.ReconcileError)
+	s.Equal(0, rep.record.ShiftCount)
+	s.Empty(rep.record.Discrepancies)
+}
+
+// TestReconcileSuite function
+func TestReconcileSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileSuite))
+}
@@ -2,8 +2,10 @@ package report
 
 import (
 	"fmt"
+	"strings"
 
 	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
 	"github.com/pulpfree/gsales-pdf-reports/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -21,9 +23,13 @@ type Shift struct {
 // GetRecord method
 func (r *Shift) GetRecord() (*model.ShiftRecord, error) {
 
+	if err := r.checkShift(); err != nil {
+		return nil, err
+	}
+
 	err := r.setRecord()
 	if err != nil {
-		return nil, &pkgerrors.StdError{Err: err.Error(), Caller: "report.GetRecord", Msg: "Failed to fetch shift record"}
+		return nil, apierr.New(apierr.GetCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "report.GetRecord", Msg: "Failed to fetch shift record"})
 	}
 
 	return r.record, nil
@@ -31,6 +37,37 @@ func (r *Shift) GetRecord() (*model.ShiftRecord, error) {
 
 // ======================== Un-exported Methods ================================================ //
 
+// checkShift method confirms the record number is one of the station's shifts on its day
+// a missing shift is reported with the shifts that do exist, the suffix is commonly mistyped
+func (r *Shift) checkShift() error {
+
+	date, err := model.RecordNumberDate(r.recordNumber)
+	if err != nil {
+		return apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: err.Error(), Caller: "report.checkShift", Msg: "Error setting input.RecordNumber"})
+	}
+
+	shifts, err := r.db.GetShifts(date, r.stationID)
+	if apierr.GetCode(err) == apierr.CodeNotFound {
+		errStr := fmt.Sprintf("No shifts with date:%s and stationID:%v", date.Format(timeFormatLong), r.stationID.Hex())
+		return apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: errStr, Caller: "report.checkShift", Msg: fmt.Sprintf("Shift %s not found, there are no shifts for this day", r.recordNumber)})
+	}
+	if err != nil {
+		return apierr.New(apierr.GetCode(err), &pkgerrors.StdError{Err: err.Error(), Caller: "report.checkShift", Msg: "Failed to fetch shift record"})
+	}
+
+	available := make([]string, len(shifts))
+	for i, shift := range shifts {
+		if shift.RecordNum == r.recordNumber {
+			return nil
+		}
+		available[i] = model.RecordNumberShift(shift.RecordNum)
+	}
+
+	errStr := fmt.Sprintf("No shift with recordNum:%s and stationID:%v", r.recordNumber, r.stationID.Hex())
+	msg := fmt.Sprintf("Shift %s not found, available shifts for this day are %s", r.recordNumber, strings.Join(available, ", "))
+	return apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Err: errStr, Caller: "report.checkShift", Msg: msg})
+}
+
 func (r *Shift) setRecord() (err error) {
 
 	shift, err := r.db.GetShift(r.recordNumber, r.stationID)
@@ -55,50 +92,20 @@ func (r *Shift) setRecord() (err error) {
 
 	// attendant values
 	adjustment := ""
-	osComplete := "false"
-	sheetComplete := "false"
 	if shift.Attendant.Adjustment != nil {
 		adjustment = *shift.Attendant.Adjustment
 	}
-	if shift.Attendant.OvershortComplete == true {
-		osComplete = "true"
-	}
-	if shift.Attendant.SheetComplete == true {
-		sheetComplete = "true"
-	}
 	attendant := model.AttendantFields{
 		AttendantAdjustment: adjustment,
 		AttendantName:       fmt.Sprintf("%s, %s", employee.NameLast, employee.NameFirst),
-		OvershortComplete:   osComplete,
+		OvershortComplete:   shift.Attendant.OvershortComplete,
 		OvershortValue:      model.SetFloat(shift.Attendant.OvershortValue),
-		SheetComplete:       sheetComplete,
-	}
-
-	// credit card values
-	cc := model.CardFields{
-		Amex:           model.SetFloat(shift.CreditCard.Amex),
-		Debit:          model.SetFloat(shift.Cash.Debit),
-		DieselDiscount: model.SetFloat(shift.Cash.DieselDiscount),
-		Discover:       model.SetFloat(shift.CreditCard.Discover),
-		Gales:          model.SetFloat(shift.CreditCard.Gales),
-		Mastercard:     model.SetFloat(shift.CreditCard.Mastercard),
-		Visa:           model.SetFloat(shift.CreditCard.Visa),
-	}
-	cc.TotalCards = cc.Amex + cc.Debit + cc.DieselDiscount + cc.Discover + cc.Gales + cc.Mastercard + cc.Visa
-
-	// cash values
-	cash := model.CashFields{
-		Cash:               model.SetFloat(shift.Cash.Bills),
-		DriveOffNSF:        model.SetFloat(shift.Cash.DriveOffNSF),
-		GalesLoyaltyRedeem: model.SetFloat(shift.Cash.GalesLoyaltyRedeem),
-		GiftCertRedeem:     model.SetFloat(shift.Cash.GiftCertRedeem),
-		LotteryPayout:      model.SetFloat(shift.Cash.LotteryPayout),
-		OSAdjusted:         model.SetFloat(shift.Cash.OSAdjusted),
-		Other:              model.SetFloat(shift.Cash.Other),
-		Payout:             model.SetFloat(shift.Cash.Payout),
-		WriteOff:           model.SetFloat(shift.Cash.WriteOff),
-	}
-	cash.TotalCash = cash.Cash + cash.DriveOffNSF + cash.GalesLoyaltyRedeem + cash.GiftCertRedeem + cash.LotteryPayout + cash.OSAdjusted + cash.Other + cash.Payout + cash.WriteOff
+		SheetComplete:       shift.Attendant.SheetComplete,
+	}
+
+	// credit card and cash values
+	cc := setCardFields(shift)
+	cash := setCashFields(shift)
 
 	// product adjustment values
 	var js []*model.NonFuelJournal
@@ -116,16 +123,7 @@ func (r *Shift) setRecord() (err error) {
 	}
 
 	// summary values
-	sum := model.ShiftSummary{
-		Fuel:            model.SetFloat(shift.Summary.FuelDollar),
-		FuelAdjust:      model.SetFloat(shift.Summary.FuelAdjust),
-		OtherFuelDollar: model.SetFloat(shift.Summary.OtherFuelDollar),
-		OtherFuelLitre:  model.SetFloat(shift.Summary.OtherFuelLitre),
-		Litres:          model.SetFloat(shift.Summary.FuelLitre),
-		NonFuel:         model.SetFloat(shift.Summary.TotalNonFuel),
-		Total:           model.SetFloat(shift.Summary.TotalSales),
-		TotalCashCards:  (cc.TotalCards + cash.TotalCash),
-	}
+	sum := setShiftSummary(shift, cc, cash)
 
 	r.record = &model.ShiftRecord{
 		AttendantFields:  attendant,
@@ -141,3 +139,71 @@ func (r *Shift) setRecord() (err error) {
 	}
 	return err
 }
+
+// ======================== Helper Functions =================================================== //
+
+// setCardFields extracts the card values from a shift and derives TotalCards
+// an incomplete shift may be missing its card or cash subdocument, missing values are zero
+func setCardFields(shift *model.Sales) model.CardFields {
+	var cc model.CardFields
+	if shift.CreditCard != nil {
+		cc.Amex = model.SetFloat(shift.CreditCard.Amex)
+		cc.Discover = model.SetFloat(shift.CreditCard.Discover)
+		cc.Gales = model.SetFloat(shift.CreditCard.Gales)
+		cc.Mastercard = model.SetFloat(shift.CreditCard.Mastercard)
+		cc.Visa = model.SetFloat(shift.CreditCard.Visa)
+	}
+	if shift.Cash != nil {
+		cc.Debit = model.SetFloat(shift.Cash.Debit)
+		cc.DieselDiscount = model.SetFloat(shift.Cash.DieselDiscount)
+	}
+	cc.TotalCards = sumCards(cc)
+
+	return cc
+}
+
+// setCashFields extracts the cash values from a shift and derives TotalCash, missing values are zero
+func setCashFields(shift *model.Sales) model.CashFields {
+	var cash model.CashFields
+	if shift.Cash != nil {
+		cash = model.CashFields{
+			Cash:               model.SetFloat(shift.Cash.Bills),
+			DriveOffNSF:        model.SetFloat(shift.Cash.DriveOffNSF),
+			GalesLoyaltyRedeem: model.SetFloat(shift.Cash.GalesLoyaltyRedeem),
+			GiftCertRedeem:     model.SetFloat(shift.Cash.GiftCertRedeem),
+			LotteryPayout:      model.SetFloat(shift.Cash.LotteryPayout),
+			OSAdjusted:         model.SetFloat(shift.Cash.OSAdjusted),
+			Other:              model.SetFloat(shift.Cash.Other),
+			Payout:             model.SetFloat(shift.Cash.Payout),
+			WriteOff:           model.SetFloat(shift.Cash.WriteOff),
+		}
+	}
+	cash.TotalCash = sumCash(cash)
+
+	return cash
+}
+
+// sumCards derives the TotalCards subtotal, shared by shift and day records
+func sumCards(cc model.CardFields) float64 {
+	return cc.Amex + cc.Debit + cc.DieselDiscount + cc.Discover + cc.Gales + cc.Mastercard + cc.Visa
+}
+
+// sumCash derives the TotalCash subtotal, shared by shift and day records
+func sumCash(cash model.CashFields) float64 {
+	return cash.Cash + cash.DriveOffNSF + cash.GalesLoyaltyRedeem + cash.GiftCertRedeem + cash.LotteryPayout + cash.OSAdjusted + cash.Other + cash.Payout + cash.WriteOff
+}
+
+// setShiftSummary extracts the sales summary from a shift, a shift without one has only its cash and card total
+func setShiftSummary(shift *model.Sales, cc model.CardFields, cash model.CashFields) model.ShiftSummary {
+	sum := model.ShiftSummary{TotalCashCards: cc.TotalCards + cash.TotalCash}
+	if shift.Summary != nil {
+		sum.Fuel = model.SetFloat(shift.Summary.FuelDollar)
+		sum.FuelAdjust = model.SetFloat(shift.Summary.FuelAdjust)
+		sum.OtherFuelDollar = model.SetFloat(shift.Summary.OtherFuelDollar)
+		sum.OtherFuelLitre = model.SetFloat(shift.Summary.OtherFuelLitre)
+		sum.Litres = model.SetFloat(shift.Summary.FuelLitre)
+		sum.NonFuel = model.SetFloat(shift.Summary.TotalNonFuel)
+		sum.Total = model.SetFloat(shift.Summary.TotalSales)
+	}
+	return sum
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+	"github.com/pulpfree/gsales-pdf-reports/model"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// shiftsDB struct stubs the shift listing, other DBHandler methods are not used
+type shiftsDB struct {
+	model.DBHandler
+	err    error
+	shifts []*model.Sales
+}
+
+// GetShifts method
+func (db *shiftsDB) GetShifts(date time.Time, stationID primitive.ObjectID) ([]*model.Sales, error) {
+	if db.err != nil {
+		return nil, db.err
+	}
+	if len(db.shifts) == 0 {
+		return nil, apierr.New(apierr.CodeNotFound, &pkgerrors.StdError{Caller: "db.GetShifts", Msg: "No records found matching criteria"})
+	}
+	return db.shifts, nil
+}
+
+// ShiftSuite struct
+type ShiftSuite struct {
+	suite.Suite
+	db *shiftsDB
+}
+
+// SetupTest method
+func (s *ShiftSuite) SetupTest() {
+	s.db = &shiftsDB{shifts: []*model.Sales{
+		{RecordNum: "2019-12-21-1"},
+		{RecordNum: "2019-12-21-2"},
+		{RecordNum: "2019-12-21-3"},
+	}}
+}
+
+// TestCheckShift method
+func (s *ShiftSuite) TestCheckShift() {
+	rep := &Shift{db: s.db, recordNumber: "2019-12-21-2"}
+	s.NoError(rep.checkShift())
+
+	rep.recordNumber = "2019-12-21-5"
+	err := rep.checkShift()
+	s.Error(err)
+	s.Equal(apierr.CodeNotFound, apierr.GetCode(err))
+
+	var e *pkgerrors.StdError
+	s.True(errors.As(err, &e))
+	s.Equal("Shift 2019-12-21-5 not found, available shifts for this day are -1, -2, -3", e.Msg)
+
+	s.db.shifts = nil
+	err = rep.checkShift()
+	s.Equal(apierr.CodeNotFound, apierr.GetCode(err))
+	s.True(errors.As(err, &e))
+	s.Equal("Shift 2019-12-21-5 not found, there are no shifts for this day", e.Msg)
+}
+
+// TestShiftSuite function
+func TestShiftSuite(t *testing.T) {
+	suite.Run(t, new(ShiftSuite))
+}
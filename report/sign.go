@@ -0,0 +1,89 @@
+package report
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// signatureExt is appended to the PDF file name for the detached signature
+const signatureExt = ".p7s"
+
+// ======================== Un-exported Methods ================================================ //
+
+// sign method returns a detached PKCS#7 signature over the complete PDF, or nil when no
+// signing certificate is configured
+func (r *Report) sign(data []byte) (sig []byte, err error) {
+	if r.cfg.SigningCert == "" || r.cfg.SigningKey == "" {
+		return nil, nil
+	}
+	return signDetached(data, r.cfg.SigningCert, r.cfg.SigningKey)
+}
+
+// ======================== Helper Functions =================================================== //
+
+// signDetached function signs data with the PEM encoded certificate and private key
+// the signature uses SHA-256 and carries the signing certificate so it can be verified on its own
+func signDetached(data []byte, certPEM, keyPEM string) ([]byte, error) {
+
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := pkcs7.NewSignedData(data)
+	if err != nil {
+		return nil, err
+	}
+	sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	if err = sd.AddSigner(cert, key, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, fmt.Errorf("Failed to add signer: %s", err.Error())
+	}
+	sd.Detach()
+
+	return sd.Finish()
+}
+
+// verifyDetached function checks sig against data, returning an error if the document was altered
+func verifyDetached(data, sig []byte) error {
+	p7, err := pkcs7.Parse(sig)
+	if err != nil {
+		return err
+	}
+	p7.Content = data
+	return p7.Verify()
+}
+
+func parseCertificate(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, errors.New("Invalid signing certificate: no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// parsePrivateKey function accepts PKCS#8, PKCS#1 RSA and SEC 1 EC private keys
+func parsePrivateKey(keyPEM string) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("Invalid signing key: no PEM data found")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("Invalid signing key: unsupported key type")
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// SignSuite struct
+type SignSuite struct {
+	suite.Suite
+	certPEM string
+	keyPEM  string
+}
+
+// SetupSuite method creates a self-signed signing certificate
+func (s *SignSuite) SetupSuite() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.NoError(err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gsales-pdf-reports test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	s.NoError(err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	s.NoError(err)
+
+	s.certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	s.keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+// TestSignDetached method
+func (s *SignSuite) TestSignDetached() {
+	doc := []byte("%PDF-1.3 shift report")
+
+	sig, err := signDetached(doc, s.certPEM, s.keyPEM)
+	s.NoError(err)
+	s.NoError(verifyDetached(doc, sig))
+
+	tampered := append([]byte{}, doc...)
+	tampered[len(tampered)-1] = 'X'
+	s.Error(verifyDetached(tampered, sig))
+}
+
+// TestSignInvalidPEM method
+func (s *SignSuite) TestSignInvalidPEM() {
+	_, err := signDetached([]byte("doc"), "", s.keyPEM)
+	s.EqualError(err, "Invalid signing certificate: no PEM data found")
+
+	_, err = signDetached([]byte("doc"), s.certPEM, "not a key")
+	s.EqualError(err, "Invalid signing key: no PEM data found")
+}
+
+// TestSignSuite function
+func TestSignSuite(t *testing.T) {
+	suite.Run(t, new(SignSuite))
+}
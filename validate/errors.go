@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"strings"
+
+	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
+)
+
+// fieldErrors struct collects every invalid input field so a request is rejected once with all of its problems
+type fieldErrors struct {
+	details []string
+	fields  []*apierr.FieldError
+}
+
+// add method records a field error, msg is returned to the client and detail is logged
+func (e *fieldErrors) add(field, msg, detail string) {
+	e.details = append(e.details, detail)
+	e.fields = append(e.fields, &apierr.FieldError{Field: field, Message: msg})
+}
+
+// err method returns the collected field errors as a validation error, or nil when there are none
+// a single field error keeps its own message, several are summarized by field name
+func (e *fieldErrors) err(caller string) error {
+	if len(e.fields) == 0 {
+		return nil
+	}
+
+	msg := e.fields[0].Message
+	if len(e.fields) > 1 {
+		names := make([]string, len(e.fields))
+		for i, f := range e.fields {
+			names[i] = f.Field
+		}
+		msg = "Error invalid input: " + strings.Join(names, ", ")
+	}
+
+	return &apierr.Error{
+		Code:   apierr.CodeValidation,
+		Err:    &pkgerrors.StdError{Err: strings.Join(e.details, "; "), Caller: caller, Msg: msg},
+		Fields: e.fields,
+	}
+}
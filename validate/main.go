@@ -3,67 +3,203 @@ package validate
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"strconv"
 	"time"
 
 	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
 	"github.com/pulpfree/gsales-pdf-reports/model"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const timeDayFormat = "2006-01-02"
 
-// SetRequest function
+// Audit log listing limits
+const (
+	defaultAuditLimit = 25
+	maxAuditLimit     = 100
+)
+
+// maxPasswordLen is the longest password the PDF standard security handler uses, longer ones are truncated
+const maxPasswordLen = 32
+
+// SetRequest function validates input against the schema of its report type, every invalid field is
+// collected and returned together in a single validation error
 func SetRequest(input *model.RequestInput) (req *model.ReportRequest, err error) {
 
-	var rt model.ReportType
+	// an empty or null request body leaves no input to validate
+	if input == nil {
+		return nil, apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: "nil input", Caller: "validate.SetRequest", Msg: "Error missing request body"})
+	}
 
+	errs := &fieldErrors{}
 	req = &model.ReportRequest{}
-	rt, err = model.ReportStringToType(input.ReportType)
+
+	rt, err := model.ReportStringToType(input.ReportType)
 	if err != nil {
-		return nil, &pkgerrors.StdError{Err: err.Error(), Caller: "validate.SetRequest", Msg: "Error missing or invalid input.ReportType"}
+		errs.add("type", "Error missing or invalid input.ReportType", err.Error())
 	}
 	req.ReportType = &rt
 
 	// We have specific fields for each report type and must validate accordingly
-	// also note, we've already validated the report type above when calling model.ReportStringToType
-	if int(*req.ReportType) == int(model.DayReport) {
-		if input.Date == "" {
-			return nil, &pkgerrors.StdError{Err: "empty input.Date", Caller: "validate.SetRequest", Msg: "Error missing input.Date"}
-		}
-		req.Date, err = time.Parse(timeDayFormat, input.Date)
-		if err != nil {
-			return nil, &pkgerrors.StdError{Err: err.Error(), Caller: "validate.SetRequest", Msg: "Error parsing time input.Date"}
+	// an invalid report type has no definition, its common fields are still checked
+	def, ok := model.LookupReportType(rt)
+	props := commonSchema
+	if ok {
+		props = schema(def)
+	}
+	for _, prop := range props {
+		if !prop.check(input, errs) {
+			continue
 		}
-	} else if int(*req.ReportType) == int(model.ShiftReport) {
-		if input.RecordNumber == "" {
-			return nil, &pkgerrors.StdError{Err: "empty input.RecordNumber", Caller: "validate.SetRequest", Msg: "Error missing input.RecordNumber"}
+		switch prop.name {
+		case dateProperty.name:
+			d, err := time.Parse(timeDayFormat, input.Date)
+			if err != nil {
+				errs.add(prop.name, prop.invalidMsg, err.Error())
+			} else if d.After(time.Now()) {
+				errs.add(prop.name, "Error input.Date cannot be in the future", fmt.Sprintf("future input.Date: %s", input.Date))
+			} else {
+				req.Date = d
+			}
+		case recordNumberProperty.name:
+			if err := testRecordNumber(input.RecordNumber); err != nil {
+				errs.add(prop.name, prop.invalidMsg, err.Error())
+			} else {
+				req.RecordNumber = input.RecordNumber
+			}
+		case stationIDProperty.name:
+			req.StationID, err = primitive.ObjectIDFromHex(input.StationID)
+			if err != nil {
+				errs.add(prop.name, prop.invalidMsg, err.Error())
+			}
+		case stationNameProperty.name:
+			// the station id takes precedence, a name is resolved to its id by the handler
+			if input.StationID == "" {
+				req.StationName = prop.value(input)
+			}
 		}
-		if err = testRecordNumber(input.RecordNumber); err != nil {
-			return nil, &pkgerrors.StdError{Err: err.Error(), Caller: "validate.SetRequest", Msg: "Error setting input.RecordNumber"}
+	}
+
+	// a date submitted with a record number must be the day of that record
+	if req.RecordNumber != "" && !req.Date.IsZero() {
+		if rd, _ := model.RecordNumberDate(req.RecordNumber); !rd.Equal(req.Date) {
+			errStr := fmt.Sprintf("input.Date %s does not match input.RecordNumber %s", input.Date, input.RecordNumber)
+			errs.add(dateProperty.name, "Error input.Date does not match input.RecordNumber", errStr)
 		}
-		req.RecordNumber = input.RecordNumber
 	}
 
-	// set station id
-	if input.StationID == "" {
-		return nil, &pkgerrors.StdError{Err: err.Error(), Caller: "validate.SetRequest", Msg: "Error missing input.StationID"}
+	// set locale, defaults to model.DefaultLocale when empty
+	req.Locale, err = model.LocaleStringToLocale(input.Locale)
+	if err != nil {
+		errs.add("locale", "Error invalid input.Locale", err.Error())
+	}
+
+	// set output format, defaults to model.FormatStandard when empty
+	req.Format, err = model.FormatStringToFormat(input.Format)
+	if err != nil {
+		errs.add("format", "Error invalid input.Format", err.Error())
+	} else if ok && !contains(def.Formats, req.Format) {
+		errs.add("format", "Error invalid input.Format", fmt.Sprintf("format %s not supported by %s reports", req.Format, def.Name))
+	}
+
+	// set optional page setup, the report layout defaults apply when empty
+	req.Orientation, err = model.OrientationStringToOrientation(input.Orientation)
+	if err != nil {
+		errs.add("orientation", "Error invalid input.Orientation", err.Error())
 	}
-	req.StationID, err = primitive.ObjectIDFromHex(input.StationID)
+	req.PageSize, err = model.PageSizeStringToPageSize(input.PageSize)
 	if err != nil {
-		return nil, &pkgerrors.StdError{Err: err.Error(), Caller: "validate.SetRequest", Msg: "Error setting input.StationID"}
+		errs.add("pageSize", "Error invalid input.PageSize", err.Error())
 	}
 
-	return req, err
+	// set optional report password, archive output is never encrypted
+	if len(input.Password) > maxPasswordLen {
+		errs.add("password", "Error invalid input.Password", fmt.Sprintf("input.Password exceeds %d characters", maxPasswordLen))
+	} else if input.Password != "" && req.Format == model.FormatArchive {
+		errs.add("password", "Error invalid input.Password", "input.Password cannot be used with archive output")
+	}
+	req.Password = input.Password
+
+	if err = errs.err("validate.SetRequest"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
+// SetAuditRequest function validates the audit log query parameters, limit defaults to 25 and may not exceed 100
+func SetAuditRequest(stationID, limit string) (req *model.AuditRequest, err error) {
+
+	req = &model.AuditRequest{Limit: defaultAuditLimit}
+
+	if stationID == "" {
+		return nil, apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: "empty stationID", Caller: "validate.SetAuditRequest", Msg: "Error missing stationID"})
+	}
+	req.StationID, err = primitive.ObjectIDFromHex(stationID)
+	if err != nil {
+		return nil, apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: err.Error(), Caller: "validate.SetAuditRequest", Msg: "Error setting stationID"})
+	}
+
+	if limit != "" {
+		req.Limit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil || req.Limit < 1 || req.Limit > maxAuditLimit {
+			errStr := fmt.Sprintf("Invalid limit submitted: %s", limit)
+			return nil, apierr.New(apierr.CodeValidation, &pkgerrors.StdError{Err: errStr, Caller: "validate.SetAuditRequest", Msg: fmt.Sprintf("Error limit must be between 1 and %d", maxAuditLimit)})
+		}
+	}
+
+	return req, nil
+}
+
+// SetShiftListRequest function validates the shift listing parameters, both the station and date are required
+func SetShiftListRequest(stationID, date string) (req *model.ShiftListRequest, err error) {
+
+	errs := &fieldErrors{}
+	req = &model.ShiftListRequest{}
+	input := &model.RequestInput{Date: date, StationID: stationID}
+
+	if stationIDProperty.check(input, errs) {
+		req.StationID, err = primitive.ObjectIDFromHex(stationID)
+		if err != nil {
+			errs.add(stationIDProperty.name, stationIDProperty.invalidMsg, err.Error())
+		}
+	}
+	if dateProperty.check(input, errs) {
+		req.Date, err = time.Parse(timeDayFormat, date)
+		if err != nil {
+			errs.add(dateProperty.name, dateProperty.invalidMsg, err.Error())
+		}
+	}
+
+	if err = errs.err("validate.SetShiftListRequest"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// testRecordNumber function checks the record number format and that its date part is a real calendar date
 func testRecordNumber(recordNumber string) error {
-	re := regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}-[0-9]$`)
-	valid := re.MatchString(recordNumber)
+	valid := recordNumberPattern.MatchString(recordNumber)
 	if valid != true {
 		errStr := fmt.Sprintf("Invalid record number submitted: %s", recordNumber)
 		return errors.New(errStr)
 	}
+	if _, err := model.RecordNumberDate(recordNumber); err != nil {
+		errStr := fmt.Sprintf("Invalid record number date submitted: %s", recordNumber)
+		return errors.New(errStr)
+	}
 
 	return nil
 }
+
+// contains function reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
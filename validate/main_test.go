@@ -3,10 +3,12 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	pkgerrors "github.com/pulpfree/go-errors"
+	"github.com/pulpfree/gsales-pdf-reports/apierr"
 	"github.com/pulpfree/gsales-pdf-reports/model"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -92,6 +94,275 @@ func (s *UnitSuite) TestInvalidReportTypeRequest() {
 	}
 }
 
+// TestSetRequestFieldErrors method
+func (s *UnitSuite) TestSetRequestFieldErrors() {
+
+	s.requestDayReport.StationID = ""
+	_, err := SetRequest(s.requestDayReport)
+	s.Error(err)
+	s.Equal(apierr.CodeValidation, apierr.GetCode(err))
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error missing input.StationID")
+	}
+
+	s.requestDayReport.StationName = " Bridge "
+	req, err := SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal("Bridge", req.StationName)
+	s.True(req.StationID.IsZero())
+
+	invalid := &model.RequestInput{
+		Date:       "2999-01-01",
+		Locale:     "de-DE",
+		ReportType: dayReport,
+		StationID:  "56cf18",
+	}
+	_, err = SetRequest(invalid)
+	s.Error(err)
+
+	var ae *apierr.Error
+	s.True(errors.As(err, &ae))
+	s.Len(ae.Fields, 3)
+	s.Equal("date", ae.Fields[0].Field)
+	s.Equal("Error input.Date cannot be in the future", ae.Fields[0].Message)
+	s.Equal("stationID", ae.Fields[1].Field)
+	s.Equal("locale", ae.Fields[2].Field)
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error invalid input: date, stationID, locale")
+	}
+
+	// an unknown report type still has its common fields checked
+	_, err = SetRequest(&model.RequestInput{ReportType: "invalid"})
+	s.True(errors.As(err, &ae))
+	s.Len(ae.Fields, 2)
+	s.Equal("type", ae.Fields[0].Field)
+	s.Equal("stationID", ae.Fields[1].Field)
+
+	// fields of other report types are not required
+	_, err = SetRequest(&model.RequestInput{ReportType: shiftReport, StationID: stationID})
+	s.True(errors.As(err, &ae))
+	s.Len(ae.Fields, 1)
+	s.Equal("recordNumber", ae.Fields[0].Field)
+	s.Equal("Error missing input.RecordNumber", ae.Fields[0].Message)
+}
+
+// TestSetNilRequest method
+func (s *UnitSuite) TestSetNilRequest() {
+
+	_, err := SetRequest(nil)
+	s.Error(err)
+	s.Equal(apierr.CodeValidation, apierr.GetCode(err))
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error missing request body")
+	}
+}
+
+// TestSetShiftRecordNumberRequest method
+func (s *UnitSuite) TestSetShiftRecordNumberRequest() {
+
+	s.requestShiftReport.Date = date
+	req, err := SetRequest(s.requestShiftReport)
+	s.NoError(err)
+	s.Equal(recordNumber, req.RecordNumber)
+
+	s.requestShiftReport.Date = "2019-12-20"
+	_, err = SetRequest(s.requestShiftReport)
+	s.Error(err)
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error input.Date does not match input.RecordNumber")
+	}
+
+	s.requestShiftReport.Date = ""
+	s.requestShiftReport.RecordNumber = "2019-02-30-1"
+	_, err = SetRequest(s.requestShiftReport)
+	s.Error(err)
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error setting input.RecordNumber")
+	}
+}
+
+// TestSchemasMatchReportTypes method checks the catalog parameters of each registered report type drive its schema
+func (s *UnitSuite) TestSchemasMatchReportTypes() {
+
+	// parameters validated by SetRequest without a schema property
+	unmapped := []string{"format", "locale", "orientation", "pageSize", "password"}
+
+	for _, def := range model.ReportTypes() {
+		props := schema(def)
+		for _, name := range append(def.Required, def.Optional...) {
+			if _, ok := properties[name]; !ok {
+				s.Contains(unmapped, name, def.Name)
+			}
+		}
+		for _, prop := range props {
+			if prop.required {
+				s.Contains(def.Required, prop.name, def.Name)
+			} else {
+				s.Contains(def.Optional, prop.name, def.Name)
+			}
+		}
+
+		input := &model.RequestInput{
+			Date:         def.Example["date"],
+			RecordNumber: def.Example["recordNumber"],
+			ReportType:   def.Example["type"],
+			StationID:    def.Example["stationID"],
+		}
+		_, err := SetRequest(input)
+		s.NoError(err, def.Name)
+	}
+
+	// the catalog returns copies, changing one leaves validation unaffected
+	defs := model.ReportTypes()
+	defs[0].Required[0] = "stationName"
+	defs[0].Example["type"] = "changed"
+	def, ok := model.LookupReportType(defs[0].Type)
+	s.True(ok)
+	s.NotEqual("stationName", def.Required[0])
+	s.NotEqual("changed", def.Example["type"])
+}
+
+// TestSetLocaleRequest method
+func (s *UnitSuite) TestSetLocaleRequest() {
+
+	req, err := SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal(model.DefaultLocale, req.Locale)
+
+	s.requestDayReport.Locale = "fr_ca"
+	req, err = SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal(model.LocaleFrCA, req.Locale)
+
+	s.requestDayReport.Locale = "de-DE"
+	_, err = SetRequest(s.requestDayReport)
+	s.Error(err)
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error invalid input.Locale")
+	}
+}
+
+// TestSetPasswordRequest method
+func (s *UnitSuite) TestSetPasswordRequest() {
+
+	s.requestShiftReport.Password = "s3cret"
+	req, err := SetRequest(s.requestShiftReport)
+	s.NoError(err)
+	s.Equal("s3cret", req.Password)
+
+	s.requestShiftReport.Password = strings.Repeat("x", maxPasswordLen+1)
+	_, err = SetRequest(s.requestShiftReport)
+	s.Error(err)
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error invalid input.Password")
+	}
+}
+
+// TestSetFormatRequest method
+func (s *UnitSuite) TestSetFormatRequest() {
+
+	req, err := SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal(model.FormatStandard, req.Format)
+
+	s.requestDayReport.Format = "Archive"
+	req, err = SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal(model.FormatArchive, req.Format)
+
+	s.requestDayReport.Password = "s3cret"
+	_, err = SetRequest(s.requestDayReport)
+	s.Error(err)
+
+	s.requestDayReport.Password = ""
+	s.requestDayReport.Format = "docx"
+	_, err = SetRequest(s.requestDayReport)
+	s.Error(err)
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error invalid input.Format")
+	}
+}
+
+// TestSetPageRequest method
+func (s *UnitSuite) TestSetPageRequest() {
+
+	req, err := SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal("", req.Orientation)
+	s.Equal("", req.PageSize)
+
+	s.requestDayReport.Orientation = "Landscape"
+	s.requestDayReport.PageSize = "a4"
+	req, err = SetRequest(s.requestDayReport)
+	s.NoError(err)
+	s.Equal(model.OrientationLandscape, req.Orientation)
+	s.Equal(model.PageSizeA4, req.PageSize)
+
+	s.requestDayReport.PageSize = "legal"
+	_, err = SetRequest(s.requestDayReport)
+	s.Error(err)
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error invalid input.PageSize")
+	}
+}
+
+// TestSetAuditRequest method
+func (s *UnitSuite) TestSetAuditRequest() {
+
+	req, err := SetAuditRequest("56cf1815982d82b0f3000001", "")
+	s.NoError(err)
+	s.Equal(int64(defaultAuditLimit), req.Limit)
+	s.Equal("56cf1815982d82b0f3000001", req.StationID.Hex())
+
+	req, err = SetAuditRequest("56cf1815982d82b0f3000001", "10")
+	s.NoError(err)
+	s.Equal(int64(10), req.Limit)
+
+	_, err = SetAuditRequest("", "10")
+	s.Error(err)
+
+	_, err = SetAuditRequest("56cf1815982d82b0f3000001", "500")
+	s.Error(err)
+	s.Equal(apierr.CodeValidation, apierr.GetCode(err))
+
+	var e *pkgerrors.StdError
+	if ok := errors.As(err, &e); ok {
+		s.Equal(e.Msg, "Error limit must be between 1 and 100")
+	}
+}
+
+// TestSetShiftListRequest method
+func (s *UnitSuite) TestSetShiftListRequest() {
+
+	req, err := SetShiftListRequest(stationID, date)
+	s.NoError(err)
+	s.Equal(stationID, req.StationID.Hex())
+	s.Equal(date, req.Date.Format(dateFormat))
+
+	_, err = SetShiftListRequest(stationID, "2019-02-30")
+	s.Error(err)
+	s.Equal(apierr.CodeValidation, apierr.GetCode(err))
+
+	_, err = SetShiftListRequest("", "")
+	var ae *apierr.Error
+	s.True(errors.As(err, &ae))
+	s.Len(ae.Fields, 2)
+}
+
 // TesttestRecordNumber method
 func (s *UnitSuite) TesttestRecordNumber() {
 
@@ -104,6 +375,11 @@ func (s *UnitSuite) TesttestRecordNumber() {
 	err = testRecordNumber(invalidRecordNumber)
 	s.Error(err)
 	s.Equal(err.Error(), fmt.Sprintf("Invalid record number submitted: %s", invalidRecordNumber))
+
+	invalidRecordNumber = "2019-13-02-1"
+	err = testRecordNumber(invalidRecordNumber)
+	s.Error(err)
+	s.Equal(err.Error(), fmt.Sprintf("Invalid record number date submitted: %s", invalidRecordNumber))
 }
 
 // TestUnitSuite function
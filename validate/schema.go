@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pulpfree/gsales-pdf-reports/model"
+)
+
+// Input patterns
+var (
+	datePattern         = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	objectIDPattern     = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+	recordNumberPattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}-[0-9]$`)
+)
+
+// property struct is a JSON-schema style rule for a single RequestInput field
+type property struct {
+	alternate  *property // a value for alternate satisfies required
+	invalidMsg string    // message when the value doesn't match pattern
+	missingMsg string    // message when a required value is empty
+	name       string    // field name as submitted, ie: stationID
+	pattern    *regexp.Regexp
+	required   bool
+	value      func(*model.RequestInput) string
+}
+
+// Properties
+var (
+	dateProperty = property{
+		invalidMsg: "Error parsing time input.Date",
+		missingMsg: "Error missing input.Date",
+		name:       "date",
+		pattern:    datePattern,
+		required:   true,
+		value:      func(in *model.RequestInput) string { return in.Date },
+	}
+	recordNumberProperty = property{
+		invalidMsg: "Error setting input.RecordNumber",
+		missingMsg: "Error missing input.RecordNumber",
+		name:       "recordNumber",
+		pattern:    recordNumberPattern,
+		required:   true,
+		value:      func(in *model.RequestInput) string { return in.RecordNumber },
+	}
+	stationIDProperty = property{
+		alternate:  &stationNameProperty,
+		invalidMsg: "Error setting input.StationID",
+		missingMsg: "Error missing input.StationID",
+		name:       "stationID",
+		pattern:    objectIDPattern,
+		required:   true,
+		value:      func(in *model.RequestInput) string { return in.StationID },
+	}
+	stationNameProperty = property{
+		name:  "stationName",
+		value: func(in *model.RequestInput) string { return strings.TrimSpace(in.StationName) },
+	}
+)
+
+// commonSchema lists the properties of every report type, a station is identified by id or name
+// it is checked on its own when the report type is unknown
+var commonSchema = []property{stationIDProperty, stationNameProperty}
+
+// properties maps the request parameters of the report catalog to their rules, parameters
+// without one, ie: locale, are validated on their own by SetRequest
+var properties = map[string]property{
+	dateProperty.name:         dateProperty,
+	recordNumberProperty.name: recordNumberProperty,
+	stationIDProperty.name:    stationIDProperty,
+	stationNameProperty.name:  stationNameProperty,
+}
+
+// schema function returns the properties of a registered report type, built from the required
+// and optional parameters of its definition
+func schema(def model.ReportDefinition) []property {
+	props := make([]property, 0, len(def.Required)+len(def.Optional))
+	for _, name := range def.Required {
+		if p, ok := properties[name]; ok {
+			p.required = true
+			props = append(props, p)
+		}
+	}
+	for _, name := range def.Optional {
+		if p, ok := properties[name]; ok {
+			props = append(props, optional(p))
+		}
+	}
+	return props
+}
+
+// check method adds a field error to errs when the property value is missing or malformed
+// and reports whether the value is usable
+func (p property) check(input *model.RequestInput, errs *fieldErrors) bool {
+	v := p.value(input)
+	if v == "" {
+		if p.required && (p.alternate == nil || p.alternate.value(input) == "") {
+			errs.add(p.name, p.missingMsg, "empty input."+p.name)
+		}
+		return false
+	}
+	if p.pattern != nil && !p.pattern.MatchString(v) {
+		errs.add(p.name, p.invalidMsg, "invalid input."+p.name+": "+v)
+		return false
+	}
+	return true
+}
+
+// optional function returns a copy of p that may be left empty
+func optional(p property) property {
+	p.required = false
+	return p
+}